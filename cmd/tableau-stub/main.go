@@ -0,0 +1,107 @@
+// Command tableau-stub runs in-memory stand-in of Tableau Server REST API, so that the CLI can be tried out without
+// touching a live server:
+//
+//	tableau-stub -addr 127.0.0.1:8080 -users users.yaml -sites marketing,sales
+//	TABLEAU_URL=http://127.0.0.1:8080/api/3.11 TABLEAU_USERNAME=admin TABLEAU_PASSWORD=password tableau-cli get user
+//
+// Users file has the same format as for update user --from-yaml; users are added to every site. Users can own content
+// given by counts in workbooks, datasources and flows keys of the user.
+package main
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"gopkg.in/yaml.v3"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "Address to listen on")
+	usersFile := flag.String("users", "", "YAML file with usernames and roles to create on every site")
+	sites := flag.String("sites", "", "Comma-separated content URLs of sites besides the Default site")
+	username := flag.String("username", "admin", "Username accepted by sign in")
+	password := flag.String("password", "password", "Password accepted by sign in")
+	flag.Parse()
+
+	s := tableautest.NewUnstartedServer()
+	s.Username = *username
+	s.Password = *password
+
+	for _, site := range strings.Split(*sites, ",") {
+		if site != "" {
+			s.AddSite(site)
+		}
+	}
+
+	if *usersFile != "" {
+		data, err := os.ReadFile(*usersFile)
+		if err != nil {
+			log.Fatalf("Couldn't read users file: %v", err)
+		}
+		var users []struct {
+			Username    string `yaml:"username"`
+			Role        string `yaml:"role"`
+			Workbooks   int    `yaml:"workbooks"`
+			Datasources int    `yaml:"datasources"`
+			Flows       int    `yaml:"flows"`
+		}
+		if err := yaml.Unmarshal(data, &users); err != nil {
+			log.Fatalf("Couldn't parse users file: %v", err)
+		}
+		for _, site := range s.Sites {
+			for _, user := range users {
+				added := site.AddUser(tableautest.User{Name: user.Username, SiteRole: user.Role})
+				for kind, count := range map[string]int{
+					"workbooks": user.Workbooks, "datasources": user.Datasources, "flows": user.Flows,
+				} {
+					for i := 1; i <= count; i++ {
+						site.AddContent(kind, fmt.Sprintf("%s %s %d", user.Username, kind, i), added.ID)
+					}
+				}
+			}
+		}
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("Couldn't listen on %s: %v", *addr, err)
+	}
+	_ = s.Listener.Close()
+	s.Listener = listener
+	s.Start()
+	defer s.Close()
+
+	fmt.Printf("TABLEAU_URL=%s\nTABLEAU_USERNAME=%s\nTABLEAU_PASSWORD=%s\n", s.BaseURL(), s.Username, s.Password)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
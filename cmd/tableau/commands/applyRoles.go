@@ -0,0 +1,96 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var (
+	applyRolesFilenameFlag string
+	applyRolesApproveFlag  bool
+)
+
+// applyRolesCmd represents the apply roles command
+var applyRolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "Raise site roles of users to the highest role required by groups they're member of",
+	Long: fmt.Sprintf(`
+Resolve members of groups listed in YAML file with the site role the members should have at least, e.g.
+
+- group: Finance
+  role: Explorer
+- group: Analysts
+  role: Creator
+
+and print plan of updates of users whose site role is lower than roles required by their groups. Administrator rights
+and license level (%s) of a role are raised separately, so administrators keep their rights, e.g.
+SiteAdministratorExplorer required to be Creator becomes SiteAdministratorCreator. Roles are never lowered, and users
+with unknown role or who aren't member of any listed group are left as they are. The plan is executed only with
+--%s flag.
+`, strings.Join(internal.LicenseLevels, ", "), ApproveFlagName),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, err := internal.ReadGroupRolesFile(applyRolesFilenameFlag)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		t := signIn()
+
+		plan, err := t.PlanGroupRoles(rules)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		if !applyRolesApproveFlag || len(plan.Steps) == 0 {
+			printOutput(plan, usersPlanView)
+			if len(plan.Steps) > 0 {
+				log.Warnf("Plan was not applied - run with --%s to apply it", ApproveFlagName)
+			}
+			return
+		}
+
+		errored := t.ApplyGroupRoles(plan)
+		printOutput(plan, usersPlanView)
+		if errored > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	applyCmd.AddCommand(applyRolesCmd)
+
+	applyRolesCmd.Flags().StringVarP(&applyRolesFilenameFlag, FilenameFlagName, "f", "",
+		"Path to YAML file with groups and site roles their members should have at least")
+	applyRolesCmd.Flags().BoolVar(&applyRolesApproveFlag, ApproveFlagName, false, "Apply the plan")
+	_ = applyRolesCmd.MarkFlagRequired(FilenameFlagName)
+}
@@ -0,0 +1,108 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+)
+
+const (
+	FilenameFlagName = "filename"
+	PruneFlagName    = "prune"
+	ApproveFlagName  = "approve"
+)
+
+var (
+	applyFilenameFlag   string
+	applyPruneFlag      bool
+	applyApproveFlag    bool
+	applyAssetsUserFlag string
+)
+
+// applyUsersCmd represents the apply users command
+var applyUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Create, update and optionally delete users to match the desired list",
+	Long: fmt.Sprintf(`
+Compare users in YAML (or CSV) file with users on the site and print plan of changes: users which are missing are
+created, users with different role are updated, and with --%s flag users which are not listed are deleted, and their
+assets moved to the user given by --%s flag. The signed-in user and the user taking over assets are never deleted.
+The plan is executed only with --%s flag. The file is a list of users like in create user --%s:
+- username: john.smith
+  role: Explorer
+Users without role are created as %s, and role of existing ones is left as it is.
+`, PruneFlagName, ExistingAssetsUserNameFlag, ApproveFlagName, FromFileFlagName, internal.DefaultRole),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if applyAssetsUserFlag == "" {
+			applyAssetsUserFlag = viper.GetString(strings.ToLower(internal.ExistingAssetsUserNameVar))
+		}
+
+		desired, err := internal.ReadUsersFile(applyFilenameFlag)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		t := signIn()
+
+		plan, err := t.PlanUsers(desired, applyPruneFlag, applyAssetsUserFlag)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		if !applyApproveFlag || len(plan.Steps) == 0 {
+			printOutput(plan, usersPlanView)
+			if len(plan.Steps) > 0 {
+				log.Warnf("Plan was not applied - run with --%s to apply it", ApproveFlagName)
+			}
+			return
+		}
+
+		errored := t.ApplyUsers(plan)
+		printOutput(plan, usersPlanView)
+		if errored > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	applyCmd.AddCommand(applyUsersCmd)
+
+	applyUsersCmd.Flags().StringVarP(&applyFilenameFlag, FilenameFlagName, "f", "",
+		"Path to YAML or CSV file with desired users")
+	applyUsersCmd.Flags().BoolVar(&applyPruneFlag, PruneFlagName, false, "Delete users which are not in the file")
+	applyUsersCmd.Flags().BoolVar(&applyApproveFlag, ApproveFlagName, false, "Apply the plan")
+	applyUsersCmd.Flags().StringVarP(&applyAssetsUserFlag, ExistingAssetsUserNameFlag, "e",
+		"", // The default is set in the command from Viper config.
+		"Username of an existing user to which assets of deleted users will be moved to.")
+	_ = applyUsersCmd.MarkFlagRequired(FilenameFlagName)
+}
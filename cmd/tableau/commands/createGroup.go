@@ -0,0 +1,117 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	MinimumSiteRoleFlagName  = "minimum-site-role"
+	ADDomainFlagName         = "ad-domain"
+	GrantLicenseModeFlagName = "grant-license-mode"
+)
+
+var (
+	createGroupMinimumSiteRoleFlag  string
+	createGroupADDomainFlag         string
+	createGroupGrantLicenseModeFlag string
+)
+
+// createGroupCmd represents the create group command
+var createGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Create local group, or import group from Active Directory",
+	Long: fmt.Sprintf(`
+Create local group, or import group with its members from Active Directory domain given by --%s flag, e.g.
+
+  create group Finance --%s my-domain.com --%s Explorer --%s onLogin
+
+Imported groups get minimum site role %s unless set otherwise. Members get the minimum site role when they sign in
+(onLogin) or when the group is synchronized (onSync), see update group --sync.
+`, ADDomainFlagName, ADDomainFlagName, MinimumSiteRoleFlagName, GrantLicenseModeFlagName,
+		internal.DefaultImportSiteRole),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		if createGroupGrantLicenseModeFlag != "" {
+			if createGroupADDomainFlag == "" {
+				log.Errorf("Invalid arguments: --%s can be set only with --%s", GrantLicenseModeFlagName,
+					ADDomainFlagName)
+				os.Exit(1)
+			}
+			mode, err := internal.ValidateGrantLicenseMode(createGroupGrantLicenseModeFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			createGroupGrantLicenseModeFlag = mode
+		}
+
+		if createGroupMinimumSiteRoleFlag != "" {
+			role, err := internal.ValidateSiteRole(createGroupMinimumSiteRoleFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			createGroupMinimumSiteRoleFlag = role
+		} else if createGroupADDomainFlag != "" {
+			createGroupMinimumSiteRoleFlag = internal.DefaultImportSiteRole
+		}
+
+		t := signIn()
+
+		group, err := t.CreateGroup(internal.Group{Name: name, Domain: createGroupADDomainFlag,
+			MinimumSiteRole: createGroupMinimumSiteRoleFlag, GrantLicenseMode: createGroupGrantLicenseModeFlag})
+		if err != nil {
+			if group != nil && group.Exists {
+				printOutput(CreateGroupResult{Group: *group, Created: false}, createGroupView)
+			} else {
+				log.Errorf("Command failed: %s", err)
+			}
+			os.Exit(1)
+		}
+
+		printOutput(CreateGroupResult{Group: *group, Created: true}, createGroupView)
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createGroupCmd)
+
+	createGroupCmd.Flags().StringVar(&createGroupMinimumSiteRoleFlag, MinimumSiteRoleFlagName, "",
+		fmt.Sprintf("Site role users get when they're added to the group, one of %s",
+			strings.Join(internal.SiteRoles, ", ")))
+	createGroupCmd.Flags().StringVar(&createGroupADDomainFlag, ADDomainFlagName, "",
+		"Active Directory domain to import the group from, e.g. my-domain.com")
+	createGroupCmd.Flags().StringVar(&createGroupGrantLicenseModeFlag, GrantLicenseModeFlagName, "",
+		fmt.Sprintf("When members of imported group get the minimum site role, one of %s",
+			strings.Join(internal.GrantLicenseModes, ", ")))
+}
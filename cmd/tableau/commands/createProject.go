@@ -0,0 +1,142 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	DescriptionFlagName        = "description"
+	ContentPermissionsFlagName = "content-permissions"
+	ParentsFlagName            = "parents"
+)
+
+var (
+	createProjectDescriptionFlag        string
+	createProjectContentPermissionsFlag string
+	createProjectParentsFlag            bool
+)
+
+// createProjectCmd represents the create project command
+var createProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Create project, optionally nested in parent project",
+	Long: fmt.Sprintf(`
+Create project given by path - names of parent projects and the project separated by /. The parent project has to
+exist, unless --%s flag is set, which creates missing parent projects too, e.g.
+
+  create project Finance/Reports/Monthly --%s --%s LockedToProject
+`, ParentsFlagName, ParentsFlagName, ContentPermissionsFlagName),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := strings.Trim(args[0], internal.ProjectPathSeparator)
+
+		if createProjectContentPermissionsFlag != "" {
+			mode, err := internal.ValidateContentPermissions(createProjectContentPermissionsFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			createProjectContentPermissionsFlag = mode
+		}
+
+		t := signIn()
+
+		tree, err := t.GetProjectTree()
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		if existing := internal.FindProject(tree, path); existing != nil {
+			printOutput(CreateProjectResult{Project: *existing, Created: false}, createProjectView)
+			os.Exit(1)
+		}
+
+		parentPath, name := internal.SplitProjectPath(path)
+		parentID, err := ensureParentProject(t, tree, parentPath)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		project, err := t.CreateProject(internal.Project{Name: name, Path: path, ParentID: parentID,
+			Description: createProjectDescriptionFlag, ContentPermissions: createProjectContentPermissionsFlag})
+		if err != nil {
+			if project != nil && project.Exists {
+				printOutput(CreateProjectResult{Project: *project, Created: false}, createProjectView)
+			} else {
+				log.Errorf("Command failed: %s", err)
+			}
+			os.Exit(1)
+		}
+
+		printOutput(CreateProjectResult{Project: *project, Created: true}, createProjectView)
+	},
+}
+
+// ensureParentProject returns ID of project with given path, which is empty for top-level projects. Missing projects
+// on the path are created if --parents flag is set; otherwise returns error.
+func ensureParentProject(t *internal.Tableau, tree []*internal.Project, path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	if parent := internal.FindProject(tree, path); parent != nil {
+		return parent.ID, nil
+	}
+	if !createProjectParentsFlag {
+		return "", fmt.Errorf("parent project %s does not exist - use --%s flag to create it", path,
+			ParentsFlagName)
+	}
+
+	grandParentPath, name := internal.SplitProjectPath(path)
+	grandParentID, err := ensureParentProject(t, tree, grandParentPath)
+	if err != nil {
+		return "", err
+	}
+
+	parent, err := t.CreateProject(internal.Project{Name: name, Path: path, ParentID: grandParentID})
+	if err != nil {
+		return "", err
+	}
+	log.Infof("Created parent project %s with ID %s", path, parent.ID)
+
+	return parent.ID, nil
+}
+
+func init() {
+	createCmd.AddCommand(createProjectCmd)
+
+	createProjectCmd.Flags().StringVar(&createProjectDescriptionFlag, DescriptionFlagName, "",
+		"Description of the project")
+	createProjectCmd.Flags().StringVar(&createProjectContentPermissionsFlag, ContentPermissionsFlagName, "",
+		fmt.Sprintf("Content permissions mode, one of %s", strings.Join(internal.ContentPermissions, ", ")))
+	createProjectCmd.Flags().BoolVarP(&createProjectParentsFlag, ParentsFlagName, "p", false,
+		"Create missing parent projects")
+}
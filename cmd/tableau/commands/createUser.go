@@ -26,54 +26,133 @@ import (
 	"fmt"
 	"github.com/davidlukac/go-tableau-cli/internal"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	createEmailFlag       string
+	createFullNameFlag    string
+	createFromFileFlag    string
+	createSiteRoleFlag    string
+	createAuthSettingFlag string
+)
+
 // createUserCmd represents the createUser command
 var createUserCmd = &cobra.Command{
 	Use:   "user",
 	Short: "Create user",
-	Args:  cobra.ExactArgs(1),
+	Long: fmt.Sprintf(`
+Create user with given username, or all users from YAML or CSV file given by %s flag, skipping existing ones.
+YAML file is a list of users, where only username is required:
+- username: john.smith
+  role: Explorer
+  authSetting: SAML
+  email: john.smith@my-domain.com
+  fullName: John Smith
+CSV file has a header row with the same column names, e.g.
+username,role,authSetting,email,fullName
+Users without role or auth setting are created with %s and %s flags (%s and %s by default).
+Site roles and auth settings are validated before any user is created.
+`, FromFileFlagName, SiteRoleFlagName, AuthSettingFlagName, internal.DefaultRole, internal.DefaultAuthSetting),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if createFromFileFlag != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		username := args[0]
-		fmt.Printf("createUser called with %s\n", username)
-
-		url := viper.GetString("tableau_url")
-		apiUsername := viper.GetString("tableau_username")
-		apiPassword := viper.GetString("tableau_password")
-		token, siteId, err := internal.Login(url, apiUsername, apiPassword)
-		if err != nil {
-			log.Errorf("Failed to log in: %s", err)
+		var err error
+		if createSiteRoleFlag, err = internal.ValidateSiteRole(createSiteRoleFlag); err != nil {
+			log.Errorf("Invalid arguments: %s", err)
+			os.Exit(1)
+		}
+		if createAuthSettingFlag, err = internal.ValidateAuthSetting(createAuthSettingFlag); err != nil {
+			log.Errorf("Invalid arguments: %s", err)
 			os.Exit(1)
 		}
 
-		t := internal.Tableau{
-			BaseURL: url,
-			Token:   token,
-			SiteID:  siteId,
+		if createFromFileFlag != "" {
+			createUsersFromFile(createFromFileFlag)
+			return
 		}
 
+		username := args[0]
+		log.Debugf("createUser called with %s", username)
+
+		t := signIn()
+
 		user, err := t.CreateUser(internal.User{
-			Exists:   false,
-			Username: username,
-			Role:     internal.DefaultRole,
+			Exists:      false,
+			Username:    username,
+			Role:        createSiteRoleFlag,
+			AuthSetting: createAuthSettingFlag,
+			Email:       createEmailFlag,
+			FullName:    createFullNameFlag,
 		})
 		if err != nil {
-			if user.Exists {
-				fmt.Printf("User %s already exists!\n", user.Username)
+			if user != nil && user.Exists {
+				printOutput(CreateUserResult{User: *user, Created: false}, createUserView)
 			} else {
 				log.Errorf("Command failed: %s", err)
 			}
 			os.Exit(1)
 		}
 
-		fmt.Printf("User %s created with ID %s (%v)\n", user.Username, user.ID, user)
+		printOutput(CreateUserResult{User: *user, Created: true}, createUserView)
 	},
 }
 
+// createUsersFromFile creates users from the file and prints summary.
+func createUsersFromFile(path string) {
+	users, err := internal.ReadUsersFile(path)
+	if err != nil {
+		log.Errorf("Command failed: %s", err)
+		os.Exit(1)
+	}
+
+	valid := true
+	for _, user := range users {
+		if user.Role == "" {
+			user.Role = createSiteRoleFlag
+		} else if user.Role, err = internal.ValidateSiteRole(user.Role); err != nil {
+			log.Errorf("User %s: %s", user.Username, err)
+			valid = false
+		}
+		if user.AuthSetting == "" {
+			user.AuthSetting = createAuthSettingFlag
+		} else if user.AuthSetting, err = internal.ValidateAuthSetting(user.AuthSetting); err != nil {
+			log.Errorf("User %s: %s", user.Username, err)
+			valid = false
+		}
+	}
+	if !valid {
+		log.Errorf("Command failed: invalid users in file %s", path)
+		os.Exit(1)
+	}
+
+	log.Infof("Creating %d users from file %s", len(users), path)
+
+	t := signIn()
+
+	summary := t.CreateUsers(users)
+	printOutput(summary, createUsersSummaryView)
+	if summary.Errored > 0 {
+		os.Exit(1)
+	}
+}
+
 func init() {
 	createCmd.AddCommand(createUserCmd)
+
+	createUserCmd.Flags().StringVar(&createEmailFlag, EmailFlagName, "", "User's email")
+	createUserCmd.Flags().StringVar(&createFullNameFlag, FullNameFlagName, "", "User's full name")
+	createUserCmd.Flags().StringVar(&createFromFileFlag, FromFileFlagName, "",
+		"Path to YAML or CSV file with users to create")
+	createUserCmd.Flags().StringVar(&createSiteRoleFlag, SiteRoleFlagName, internal.DefaultRole,
+		fmt.Sprintf("Site role of created user(s), one of %s", strings.Join(internal.SiteRoles, ", ")))
+	createUserCmd.Flags().StringVar(&createAuthSettingFlag, AuthSettingFlagName, internal.DefaultAuthSetting,
+		fmt.Sprintf("Auth setting of created user(s), one of %s", strings.Join(internal.AuthSettings, ", ")))
 }
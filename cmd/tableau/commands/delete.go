@@ -42,4 +42,6 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+
+	deleteCmd.PersistentFlags().BoolVarP(&yesFlag, YesFlagName, "y", false, "Delete without asking for confirmation")
 }
@@ -0,0 +1,69 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteGroupCmd represents the delete group command
+var deleteGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Delete group by name; users in the group are not deleted",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		t := signIn()
+
+		group, err := t.GetGroup(name)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		if !group.Exists {
+			printOutput(DeleteGroupResult{Name: name, Deleted: false}, deleteGroupView)
+			os.Exit(1)
+		}
+
+		if !confirm(fmt.Sprintf("Delete group %s?", group.Name)) {
+			log.Errorf("Deletion was not confirmed - no group was deleted")
+			os.Exit(1)
+		}
+
+		if _, err := t.DeleteGroup(group.ID); err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		printOutput(DeleteGroupResult{Name: group.Name, ID: group.ID, Deleted: true}, deleteGroupView)
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteGroupCmd)
+}
@@ -0,0 +1,78 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteProjectCmd represents the delete project command
+var deleteProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Delete project by path, together with its nested projects and all their content",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+
+		t := signIn()
+
+		tree, err := t.GetProjectTree()
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		project := internal.FindProject(tree, path)
+		if project == nil {
+			printOutput(DeleteProjectResult{Path: path, Deleted: false}, deleteProjectView)
+			os.Exit(1)
+		}
+
+		nested := len(internal.SubTree(tree, project)) - 1
+		question := fmt.Sprintf("Delete project %s with all its content?", project.Path)
+		if nested > 0 {
+			question = fmt.Sprintf("Delete project %s with %d nested project(s) and all their content?",
+				project.Path, nested)
+		}
+		if !confirm(question) {
+			log.Errorf("Deletion was not confirmed - no project was deleted")
+			os.Exit(1)
+		}
+
+		if _, err := t.DeleteProject(project.ID); err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		printOutput(DeleteProjectResult{Path: project.Path, ID: project.ID, Deleted: true, NestedProjects: nested},
+			deleteProjectView)
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteProjectCmd)
+}
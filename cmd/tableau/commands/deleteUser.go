@@ -32,66 +32,228 @@ import (
 	"strings"
 )
 
-const ExistingAssetsUserNameFlag = "existing-assets-user-name"
+const (
+	ExistingAssetsUserNameFlag = "existing-assets-user-name"
+	ForceFlagName              = "force"
+)
 
-var ExistingAssetsUserName string
+var (
+	ExistingAssetsUserName string
+	deleteFromFileFlag     string
+	deleteFiltersFlag      []string
+	deleteForceFlag        bool
+)
 
 // deleteUserCmd represents the deleteUser command
 var deleteUserCmd = &cobra.Command{
 	Use:   "user",
-	Short: "Delete user by username",
-	Args:  cobra.ExactArgs(1),
+	Short: "Delete user by username, or users listed in file or matching filter",
+	Long: fmt.Sprintf(`
+Delete user by username, users listed in YAML or CSV file given by --%s flag (only usernames are used, see
+create user), or users matching --filter expressions (not combined with the file), e.g. users who haven't logged in
+since 2025:
+
+  delete user --filter lastLogin:lt:2025-01-01T00:00:00Z -e john.smith
+
+Assets of deleted users are moved to the user given by -e flag, who must exist on the site and have a site role
+which can own content - otherwise nothing is deleted, unless --%s flag is set. The signed-in user and the user taking
+over assets are never deleted. Users to delete are listed with counts of content they own, and the deletion has to be
+confirmed, unless --%s flag is set.
+`, FromFileFlagName, ForceFlagName, YesFlagName),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if deleteFromFileFlag != "" && len(deleteFiltersFlag) > 0 {
+			return fmt.Errorf("--%s can't be combined with --filter", FromFileFlagName)
+		}
+		if deleteFromFileFlag != "" || len(deleteFiltersFlag) > 0 {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		username := args[0]
 		if ExistingAssetsUserName == "" {
 			ExistingAssetsUserName = viper.GetString(strings.ToLower(internal.ExistingAssetsUserNameVar))
 		}
 
+		if len(args) == 0 {
+			deleteUsers()
+			return
+		}
+
+		username := args[0]
+
 		log.Debugf("delete user %s called, existing user name is %s\n", username, ExistingAssetsUserName)
 
-		url := viper.GetString("tableau_url")
-		apiUsername := viper.GetString("tableau_username")
-		apiPassword := viper.GetString("tableau_password")
-		token, siteId, err := internal.Login(url, apiUsername, apiPassword)
+		t := signIn()
+
+		user, err := t.GetUser(username)
 		if err != nil {
-			log.Errorf("Failed to log in: %s", err)
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		if !user.Exists {
+			printOutput(DeleteUserResult{Username: username, Deleted: false}, deleteUserView)
 			os.Exit(1)
 		}
 
-		t := internal.Tableau{
-			BaseURL: url,
-			Token:   token,
-			SiteID:  siteId,
+		existingAssetsUserID := existingAssetsUserID(t)
+		if user.ID == existingAssetsUserID {
+			log.Errorf("Command failed: user %s takes over assets of the deleted user - no user was deleted",
+				user.Username)
+			os.Exit(1)
+		}
+		if user.ID == t.UserID {
+			log.Errorf("Command failed: user %s is signed in - no user was deleted", user.Username)
+			os.Exit(1)
 		}
 
-		user, err := t.GetUser(username)
-		if !user.Exists && err == nil {
-			fmt.Printf("User %s does not exist - nothing to delete!", username)
+		_, _ = fmt.Fprintf(os.Stderr, "User to delete:\n  %s\n", describeForDeletion(t, user, existingAssetsUserID))
+		if !confirm(fmt.Sprintf("Delete user %s?", user.Username)) {
+			log.Errorf("Deletion was not confirmed - no user was deleted")
 			os.Exit(1)
 		}
 
-		existingAssetsUserID := ""
-		if ExistingAssetsUserName != "" {
-			existingAssetsUser, err := t.GetUser(ExistingAssetsUserName)
-			if !existingAssetsUser.Exists && err == nil {
-				fmt.Printf("User %s does not exist - can not move existing assets to them!", username)
-			}
-			existingAssetsUserID = existingAssetsUser.ID
+		result := deleteUser(t, user, existingAssetsUserID)
+		if !result.Deleted {
+			log.Errorf("Command failed: %s", result.Error)
+			os.Exit(1)
 		}
 
-		_, err = t.DeleteUser(user.ID, existingAssetsUserID)
-		if err != nil {
+		printOutput(result, deleteUserView)
+	},
+}
+
+// deleteUsers deletes users listed in file or matching filter, and prints summary.
+func deleteUsers() {
+	var listed []*internal.User
+	if deleteFromFileFlag != "" {
+		var err error
+		if listed, err = internal.ReadUsersFile(deleteFromFileFlag); err != nil {
 			log.Errorf("Command failed: %s", err)
 			os.Exit(1)
 		}
+	}
 
-		if ExistingAssetsUserName == "" {
-			fmt.Printf("User %s deleted from the server\n", username)
+	t := signIn()
+
+	summary := DeleteUsersSummary{Users: make([]DeleteUserResult, 0)}
+
+	var users []*internal.User
+	if deleteFromFileFlag != "" {
+		for _, u := range listed {
+			user, err := t.GetUser(u.Username)
+			if err != nil {
+				log.Errorf("Command failed: %s", err)
+				os.Exit(1)
+			}
+			if !user.Exists {
+				log.Warnf("User %s does not exist - skipping", u.Username)
+				summary.NotFound++
+				summary.Users = append(summary.Users, DeleteUserResult{Username: u.Username})
+				continue
+			}
+			users = append(users, user)
+		}
+	} else {
+		var err error
+		if users, err = t.GetUsers(internal.ListQuery{Filters: deleteFiltersFlag}); err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	existingAssetsUserID := existingAssetsUserID(t)
+
+	toDelete := make([]*internal.User, 0, len(users))
+	for _, user := range users {
+		if user.ID == t.UserID || user.ID == existingAssetsUserID {
+			log.Warnf("User %s is signed in or takes over assets - skipping", user.Username)
+			continue
+		}
+		toDelete = append(toDelete, user)
+	}
+
+	if len(toDelete) > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Users to delete:\n")
+		for _, user := range toDelete {
+			_, _ = fmt.Fprintf(os.Stderr, "  %s\n", describeForDeletion(t, user, existingAssetsUserID))
+		}
+		if !confirm(fmt.Sprintf("Delete %d user(s)?", len(toDelete))) {
+			log.Errorf("Deletion was not confirmed - no user was deleted")
+			os.Exit(1)
+		}
+	}
+
+	for idx, user := range toDelete {
+		result := deleteUser(t, user, existingAssetsUserID)
+		if result.Deleted {
+			summary.Deleted++
+			log.Infof("[%d/%d] User %s deleted", idx+1, len(toDelete), user.Username)
 		} else {
-			fmt.Printf("User %s delete from the server, existing assets moved to user %s\n", username,
-				ExistingAssetsUserName)
+			summary.Errored++
+			log.Errorf("[%d/%d] Failed to delete user %s: %s", idx+1, len(toDelete), user.Username, result.Error)
 		}
-	},
+		summary.Users = append(summary.Users, result)
+	}
+
+	printOutput(summary, deleteUsersSummaryView)
+	if summary.Errored > 0 {
+		os.Exit(1)
+	}
+}
+
+// existingAssetsUserID returns ID of the user to move assets of deleted users to, or empty string if not set.
+// Exits if the user doesn't exist or can't own content, unless force flag is set; then the assets are not moved.
+func existingAssetsUserID(t *internal.Tableau) string {
+	if ExistingAssetsUserName == "" {
+		return ""
+	}
+
+	existingAssetsUser, err := t.GetAssetsUser(ExistingAssetsUserName)
+	if existingAssetsUser == nil {
+		log.Errorf("Command failed: %s", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		if !deleteForceFlag {
+			log.Errorf("Command failed: %s - no user was deleted, use --%s to delete anyway", err, ForceFlagName)
+			os.Exit(1)
+		}
+		log.Warnf("%s - deleting anyway, content of deleted users will not be moved to another user", err)
+		return ""
+	}
+	return existingAssetsUser.ID
+}
+
+// describeForDeletion returns username, site role and counts of content owned by the user.
+func describeForDeletion(t *internal.Tableau, user *internal.User, existingAssetsUserID string) string {
+	content, err := t.GetOwnedContent(user)
+	if err != nil {
+		log.Warnf("%s", err)
+		return fmt.Sprintf("%s (%s) - owned content unknown", user.Username, user.Role)
+	}
+
+	description := fmt.Sprintf("%s (%s) - owns %d workbooks, %d data sources, %d flows", user.Username, user.Role,
+		content.Workbooks, content.Datasources, content.Flows)
+	if content.Total() > 0 && existingAssetsUserID == "" {
+		description += " which won't be moved to another user"
+	}
+	return description
+}
+
+// deleteUser deletes the user and moves their assets, and returns the result.
+func deleteUser(t *internal.Tableau, user *internal.User, existingAssetsUserID string) DeleteUserResult {
+	result := DeleteUserResult{Username: user.Username, ID: user.ID}
+
+	if _, err := t.DeleteUser(user.ID, existingAssetsUserID); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Deleted = true
+	if existingAssetsUserID != "" {
+		result.AssetsMovedTo = ExistingAssetsUserName
+	}
+	return result
 }
 
 func init() {
@@ -100,4 +262,11 @@ func init() {
 	deleteUserCmd.Flags().StringVarP(&ExistingAssetsUserName, ExistingAssetsUserNameFlag, "e",
 		"", // The default is set in the command from Viper config.
 		"Username of an existing user to which assets will be moved to.")
+	deleteUserCmd.Flags().BoolVar(&deleteForceFlag, ForceFlagName, false,
+		"Delete even if the user to move assets to doesn't exist or can't own content; the assets are then not moved")
+	deleteUserCmd.Flags().StringVar(&deleteFromFileFlag, FromFileFlagName, "",
+		"Path to YAML or CSV file with users to delete")
+	deleteUserCmd.Flags().StringArrayVar(&deleteFiltersFlag, "filter", nil,
+		"Delete users matching filter expression field:operator:value, e.g. lastLogin:lt:2025-01-01T00:00:00Z; "+
+			"can be repeated")
 }
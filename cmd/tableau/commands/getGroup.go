@@ -0,0 +1,109 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	groupFiltersFlag []string
+	groupSortFlag    []string
+	groupMembersFlag bool
+)
+
+// getGroupCmd represents the get group command
+var getGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Get and print existing group(s)",
+	Long: `
+Get group by name, or list all groups, optionally filtered and sorted, e.g. groups imported from Active Directory:
+
+  get group --filter domainName:eq:my-domain.com --sort name:asc
+
+With --members flag, members of the group are listed instead of the group.
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 && groupMembersFlag {
+			log.Errorf("Invalid arguments: members flag can be used only with group name")
+			os.Exit(1)
+		}
+		if len(args) > 0 && (len(groupFiltersFlag) > 0 || len(groupSortFlag) > 0) {
+			log.Errorf("Invalid arguments: filter and sort flags can be used only to list groups")
+			os.Exit(1)
+		}
+
+		t := signIn()
+
+		if len(args) == 0 {
+			groups, err := t.GetGroups(internal.ListQuery{Filters: groupFiltersFlag, Sort: groupSortFlag})
+			if err != nil {
+				log.Errorf("Command failed: %s", err)
+				os.Exit(1)
+			}
+			if len(groups) == 0 {
+				log.Info("No groups were found")
+			}
+
+			printOutput(groups, groupView)
+		} else {
+			name := args[0]
+			group, err := t.GetGroup(name)
+			if err != nil {
+				log.Errorf("Command failed: %s", err)
+				os.Exit(1)
+			}
+			if !group.Exists {
+				group.Name = name
+				printOutput(group, groupView)
+				os.Exit(1)
+			}
+
+			if groupMembersFlag {
+				users, err := t.GetGroupUsers(group.ID, internal.ListQuery{})
+				if err != nil {
+					log.Errorf("Command failed: %s", err)
+					os.Exit(1)
+				}
+				printOutput(users, userView)
+				return
+			}
+
+			printOutput(group, groupView)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getGroupCmd)
+
+	getGroupCmd.Flags().StringArrayVar(&groupFiltersFlag, "filter", nil,
+		"Filter expression field:operator:value, e.g. name:eq:Finance; can be repeated")
+	getGroupCmd.Flags().StringSliceVar(&groupSortFlag, "sort", nil, "Sort expression field:direction, e.g. name:asc")
+	getGroupCmd.Flags().BoolVar(&groupMembersFlag, "members", false, "List members of the group")
+}
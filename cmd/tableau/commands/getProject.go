@@ -0,0 +1,88 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var projectTreeFlag bool
+
+// getProjectCmd represents the get project command
+var getProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Get and print existing project(s)",
+	Long: `
+Get project by path - names of the project and its parent projects separated by /, e.g. Finance/Reports - or list
+all projects ordered as their tree. With --tree flag, projects (or the project and its nested projects) are printed
+as indented tree:
+
+  get project Finance --tree
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		view := projectView
+		if projectTreeFlag {
+			view = projectTreeView
+		}
+
+		t := signIn()
+
+		tree, err := t.GetProjectTree()
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			if len(tree) == 0 {
+				log.Info("No projects were found")
+			}
+			printOutput(tree, view)
+			return
+		}
+
+		project := internal.FindProject(tree, args[0])
+		if project == nil {
+			printOutput(&internal.Project{Path: args[0]}, projectView)
+			os.Exit(1)
+		}
+
+		if projectTreeFlag {
+			printOutput(internal.SubTree(tree, project), view)
+			return
+		}
+
+		printOutput(project, view)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getProjectCmd)
+
+	getProjectCmd.Flags().BoolVar(&projectTreeFlag, "tree", false, "Print projects as indented tree")
+}
@@ -25,57 +25,75 @@ THE SOFTWARE.
 import (
 	"fmt"
 	"github.com/davidlukac/go-tableau-cli/internal"
+	"github.com/davidlukac/go-tableau-cli/internal/render"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	userSiteRolesFlag       []string
+	userAuthSettingFlag     string
+	userNameContainsFlag    string
+	userLastLoginSinceFlag  string
+	userLastLoginBeforeFlag string
+	userFiltersFlag         []string
+	userSortFlag            []string
+	userFieldsFlag          []string
+	userGroupsFlag          bool
+)
+
 // getUserCmd represents the getUser command
 var getUserCmd = &cobra.Command{
-	Use:    "user",
-	Short:  "Get and print existing user(s)",
-	Args:   cobra.MinimumNArgs(0),
-	PreRun: internal.LoggingSetup,
+	Use:   "user",
+	Short: "Get and print existing user(s)",
+	Long: `
+Get user by username, or list all users. The list can be narrowed down by filter flags, which are combined with AND,
+sorted and limited to selected fields, e.g. Creators who haven't logged in since 2026:
+
+  get user --site-role Creator --last-login-before 2026-01-01 --sort lastLogin:asc
+
+Filter, sort and fields expressions are passed to the server as they are, see
+https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_filtering_and_sorting.htm
+
+With --groups flag, groups the user is member of are listed instead of the user.
+`,
+	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		url := viper.GetString("tableau_url")
-		apiUsername := viper.GetString("tableau_username")
-		apiPassword := viper.GetString("tableau_password")
-		token, siteId, err := internal.Login(url, apiUsername, apiPassword)
+		query, err := userQueryFromFlags()
 		if err != nil {
-			log.Errorf("Failed to log in: %s", err)
+			log.Errorf("Invalid arguments: %s", err)
 			os.Exit(1)
 		}
-
-		t := internal.Tableau{
-			BaseURL: url,
-			Token:   token,
-			SiteID:  siteId,
+		if len(args) == 0 && userGroupsFlag {
+			log.Errorf("Invalid arguments: groups flag can be used only with username")
+			os.Exit(1)
+		}
+		if len(args) > 0 && (len(query.Filters) > 0 || len(query.Sort) > 0 || len(query.Fields) > 0) {
+			log.Errorf("Invalid arguments: filter, sort and fields flags can be used only to list users")
+			os.Exit(1)
 		}
 
+		t := signIn()
+
 		if len(args) == 0 {
 			log.Debugf("Fetching all users")
 
-			users, err := t.GetUsers()
+			if render.Streams(outputFlag) {
+				streamOutput(t.Users(query), userView)
+				return
+			}
+
+			users, err := t.GetUsers(query)
 			if err != nil {
 				log.Errorf("Command failed: %s", err)
 				os.Exit(1)
 			}
 
-			if outputFlag == "yaml" {
-				yamlData, err := yaml.Marshal(&users)
-				if err != nil {
-					log.Errorf("Error marshaling user list to YAML! %s", err)
-					os.Exit(1)
-				}
-				fmt.Println(string(yamlData))
-			} else {
-				for _, user := range users {
-					fmt.Printf("%s (%s) - %s\n", user.Username, user.ID, user.Role)
-				}
-			}
+			printOutput(users, userView)
 
 		} else {
 			username := args[0]
@@ -87,16 +105,97 @@ var getUserCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
-			if user.Exists {
-				fmt.Printf("%s (%s) - %s\n", user.Username, user.ID, user.Role)
-			} else {
-				fmt.Printf("User %s does not exist!\n", username)
+			if !user.Exists {
+				user.Username = username
+			}
+
+			if userGroupsFlag && user.Exists {
+				groups, err := t.GetUserGroups(user.ID, internal.ListQuery{})
+				if err != nil {
+					log.Errorf("Command failed: %s", err)
+					os.Exit(1)
+				}
+				printOutput(groups, groupView)
+				return
+			}
+
+			printOutput(user, userView)
+
+			if !user.Exists {
 				os.Exit(1)
 			}
 		}
 	},
 }
 
+// userQueryFromFlags returns query of users list from filter, sort and fields flags.
+func userQueryFromFlags() (internal.ListQuery, error) {
+	query := internal.ListQuery{
+		Filters: append([]string{}, userFiltersFlag...),
+		Sort:    userSortFlag,
+		Fields:  userFieldsFlag,
+	}
+
+	if len(userSiteRolesFlag) == 1 {
+		query.Filters = append(query.Filters, internal.Filter("siteRole", "eq", userSiteRolesFlag[0]))
+	} else if len(userSiteRolesFlag) > 1 {
+		query.Filters = append(query.Filters,
+			internal.Filter("siteRole", "in", fmt.Sprintf("[%s]", strings.Join(userSiteRolesFlag, ","))))
+	}
+	if userAuthSettingFlag != "" {
+		query.Filters = append(query.Filters, internal.Filter("authSetting", "eq", userAuthSettingFlag))
+	}
+	if userNameContainsFlag != "" {
+		query.Filters = append(query.Filters, internal.Filter("name", "has", userNameContainsFlag))
+	}
+	if userLastLoginSinceFlag != "" {
+		since, err := parseDate(userLastLoginSinceFlag)
+		if err != nil {
+			return query, err
+		}
+		query.Filters = append(query.Filters, internal.Filter("lastLogin", "gte", since))
+	}
+	if userLastLoginBeforeFlag != "" {
+		before, err := parseDate(userLastLoginBeforeFlag)
+		if err != nil {
+			return query, err
+		}
+		query.Filters = append(query.Filters, internal.Filter("lastLogin", "lt", before))
+	}
+
+	return query, nil
+}
+
+// parseDate parses date (2006-01-02) or date and time (RFC 3339) and formats it as expected in filters.
+func parseDate(value string) (string, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		date, err = time.Parse(time.RFC3339, value)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid date '%s' - use YYYY-MM-DD or RFC 3339 format", value)
+	}
+	return date.UTC().Format("2006-01-02T15:04:05Z"), nil
+}
+
 func init() {
 	getCmd.AddCommand(getUserCmd)
+
+	getUserCmd.Flags().StringSliceVar(&userSiteRolesFlag, SiteRoleFlagName, nil,
+		"List only users with given site role(s), e.g. Creator,Explorer")
+	getUserCmd.Flags().StringVar(&userAuthSettingFlag, AuthSettingFlagName, "",
+		"List only users with given auth setting, e.g. SAML")
+	getUserCmd.Flags().StringVar(&userNameContainsFlag, "name-contains", "",
+		"List only users whose username contains given text")
+	getUserCmd.Flags().StringVar(&userLastLoginSinceFlag, "last-login-since", "",
+		"List only users who logged in at or after given date (YYYY-MM-DD or RFC 3339)")
+	getUserCmd.Flags().StringVar(&userLastLoginBeforeFlag, "last-login-before", "",
+		"List only users who last logged in before given date (YYYY-MM-DD or RFC 3339)")
+	getUserCmd.Flags().StringArrayVar(&userFiltersFlag, "filter", nil,
+		"Filter expression field:operator:value, e.g. name:eq:john.smith; can be repeated")
+	getUserCmd.Flags().StringSliceVar(&userSortFlag, "sort", nil,
+		"Sort expression field:direction, e.g. lastLogin:desc,name:asc")
+	getUserCmd.Flags().StringSliceVar(&userFieldsFlag, "fields", nil,
+		"Fields to fetch, e.g. _all_ or _default_,email,lastLogin")
+	getUserCmd.Flags().BoolVar(&userGroupsFlag, "groups", false, "List groups the user is member of")
 }
@@ -23,11 +23,11 @@ THE SOFTWARE.
 */
 
 import (
-	"fmt"
 	"github.com/davidlukac/go-tableau-cli/internal"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -38,15 +38,31 @@ var loginCmd = &cobra.Command{
 	Short: "Performs login and prints site ID and user ID used for the login.",
 	Run: func(cmd *cobra.Command, args []string) {
 		url := viper.GetString("tableau_url")
-		username := viper.GetString("tableau_username")
-		password := viper.GetString("tableau_password")
-		log.Infof("Logging in into %s as %s...", url, username)
-		token, siteId, err := internal.Login(url, username, password)
+		credentials := internal.LoadCredentials()
+		site := viper.GetString(strings.ToLower(internal.SiteVar))
+		if credentials.UsesJWT() {
+			log.Infof("Logging in into %s (site '%s') with connected app %s as %s...", url, site,
+				credentials.ClientID, credentials.Username)
+		} else if credentials.UsesToken() {
+			log.Infof("Logging in into %s (site '%s') with token %s...", url, site, credentials.TokenName)
+		} else {
+			log.Infof("Logging in into %s (site '%s') as %s...", url, site, credentials.Username)
+		}
+		session, err := internal.Login(url, credentials, site)
 		if err != nil {
 			log.Errorf("Command failed: %s", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Successfully logged into %s (site ID %s); token is %s\n", url, siteId, token)
+
+		sessionFile, err := internal.SessionFilePath()
+		if err == nil {
+			err = session.Save(sessionFile)
+		}
+		if err != nil {
+			log.Warnf("Failed to cache session: %s", err)
+		}
+
+		printOutput(session, sessionView)
 	},
 }
 
@@ -0,0 +1,391 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal"
+	"github.com/davidlukac/go-tableau-cli/internal/render"
+	log "github.com/sirupsen/logrus"
+	"os"
+)
+
+// CreateUserResult is printed by create user command; Created is false if the user already existed.
+type CreateUserResult struct {
+	internal.User `yaml:",inline"`
+	Created       bool `json:"created" yaml:"created"`
+}
+
+// CreateGroupResult is printed by create group command; Created is false if the group already existed.
+type CreateGroupResult struct {
+	internal.Group `yaml:",inline"`
+	Created        bool `json:"created" yaml:"created"`
+}
+
+// DeleteGroupResult is printed by delete group command.
+type DeleteGroupResult struct {
+	Name    string `json:"name" yaml:"name"`
+	ID      string `json:"id" yaml:"id"`
+	Deleted bool   `json:"deleted" yaml:"deleted"`
+}
+
+// CreateProjectResult is printed by create project command.
+type CreateProjectResult struct {
+	internal.Project `yaml:",inline"`
+	Created          bool `json:"created" yaml:"created"`
+}
+
+// DeleteProjectResult is printed by delete project command.
+type DeleteProjectResult struct {
+	Path           string `json:"path" yaml:"path"`
+	ID             string `json:"id" yaml:"id"`
+	Deleted        bool   `json:"deleted" yaml:"deleted"`
+	NestedProjects int    `json:"nestedProjects" yaml:"nestedProjects"`
+}
+
+// DeleteUserResult is printed by delete user command.
+type DeleteUserResult struct {
+	Username      string `json:"username" yaml:"username"`
+	ID            string `json:"id" yaml:"id"`
+	Deleted       bool   `json:"deleted" yaml:"deleted"`
+	AssetsMovedTo string `json:"assetsMovedTo,omitempty" yaml:"assetsMovedTo,omitempty"`
+	Error         string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// LogoutResult is printed by logout command; LoggedOut is false if there was no cached session.
+type LogoutResult struct {
+	BaseURL   string `json:"baseUrl" yaml:"baseUrl"`
+	SiteID    string `json:"siteId" yaml:"siteId"`
+	LoggedOut bool   `json:"loggedOut" yaml:"loggedOut"`
+}
+
+// DeleteUsersSummary is printed by delete user command with users file or filter.
+type DeleteUsersSummary struct {
+	Deleted  int                `json:"deleted" yaml:"deleted"`
+	NotFound int                `json:"notFound" yaml:"notFound"`
+	Errored  int                `json:"errored" yaml:"errored"`
+	Users    []DeleteUserResult `json:"users" yaml:"users"`
+}
+
+func (s DeleteUsersSummary) Items() interface{} {
+	return s.Users
+}
+
+// UpdateUserResult is outcome of updating one user from YAML file.
+type UpdateUserResult struct {
+	Username     string `json:"username" yaml:"username"`
+	Role         string `json:"role" yaml:"role"`
+	PreviousRole string `json:"previousRole,omitempty" yaml:"previousRole,omitempty"`
+	Result       string `json:"result" yaml:"result"`
+	Error        string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Results of updating a user.
+const (
+	UpdateResultSame     = "same"
+	UpdateResultUpdated  = "updated"
+	UpdateResultNotFound = "notFound"
+	UpdateResultError    = "error"
+)
+
+// UpdateUsersSummary is printed by update user command with YAML file.
+type UpdateUsersSummary struct {
+	AlreadySame int                `json:"alreadySame" yaml:"alreadySame"`
+	Updated     int                `json:"updated" yaml:"updated"`
+	NotFound    int                `json:"notFound" yaml:"notFound"`
+	Errored     int                `json:"errored" yaml:"errored"`
+	Users       []UpdateUserResult `json:"users" yaml:"users"`
+}
+
+func (s UpdateUsersSummary) Items() interface{} {
+	return s.Users
+}
+
+// GroupMemberResult is outcome of adding or removing one member of a group.
+type GroupMemberResult struct {
+	Username string `json:"username" yaml:"username"`
+	Result   string `json:"result" yaml:"result"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Results of adding or removing a member of a group.
+const (
+	MemberResultAdded    = "added"
+	MemberResultRemoved  = "removed"
+	MemberResultSame     = "same"
+	MemberResultNotFound = "notFound"
+	MemberResultError    = "error"
+)
+
+// GroupMembersSummary is printed by update group command when members are added or removed.
+type GroupMembersSummary struct {
+	Group     string              `json:"group" yaml:"group"`
+	Added     int                 `json:"added" yaml:"added"`
+	Removed   int                 `json:"removed" yaml:"removed"`
+	Unchanged int                 `json:"unchanged" yaml:"unchanged"`
+	NotFound  int                 `json:"notFound" yaml:"notFound"`
+	Errored   int                 `json:"errored" yaml:"errored"`
+	Users     []GroupMemberResult `json:"users" yaml:"users"`
+}
+
+func (s GroupMembersSummary) Items() interface{} {
+	return s.Users
+}
+
+var userView = render.View{
+	Text: `{{if .Exists}}{{.Username}} ({{.ID}}) - {{.Role}}{{with .FullName}} - {{.}}{{end}}{{with .Email}} <{{.}}>{{end}}` +
+		`{{with .LastLogin}} - last login {{.}}{{end}}{{else}}User {{.Username}} does not exist!{{end}}`,
+	Columns: []render.Column{
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Site Role", Value: "{{.Role}}"},
+		{Header: "Auth Setting", Value: "{{.AuthSetting}}"},
+		{Header: "Email", Value: "{{.Email}}"},
+		{Header: "Full Name", Value: "{{.FullName}}"},
+		{Header: "Last Login", Value: "{{.LastLogin}}"},
+		{Header: "External Auth User ID", Value: "{{.ExternalAuthUserID}}"},
+		{Header: "Locale", Value: "{{.Locale}}"},
+		{Header: "Language", Value: "{{.Language}}"},
+	},
+}
+
+var createUserView = render.View{
+	Text: `{{if .Created}}User {{.Username}} created with ID {{.ID}}{{else}}User {{.Username}} already exists!{{end}}`,
+	Columns: append(userView.Columns[:len(userView.Columns):len(userView.Columns)],
+		render.Column{Header: "Created", Value: "{{.Created}}"}),
+}
+
+var createUsersSummaryView = render.View{
+	Text: "\nCreated: {{.Created}}\nAlready existing: {{.Existing}}\nError: {{.Errored}}",
+	Columns: []render.Column{
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Site Role", Value: "{{.Role}}"},
+		{Header: "Auth Setting", Value: "{{.AuthSetting}}"},
+		{Header: "Result", Value: "{{.Result}}"},
+		{Header: "Error", Value: "{{.Error}}"},
+	},
+}
+
+var deleteUserView = render.View{
+	Text: `{{if not .Deleted}}User {{.Username}} does not exist - nothing to delete!` +
+		`{{else if .AssetsMovedTo}}User {{.Username}} deleted from the server, existing assets moved to user ` +
+		`{{.AssetsMovedTo}}{{else}}User {{.Username}} deleted from the server{{end}}`,
+	Columns: []render.Column{
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Deleted", Value: "{{.Deleted}}"},
+		{Header: "Assets Moved To", Value: "{{.AssetsMovedTo}}"},
+		{Header: "Error", Value: "{{.Error}}"},
+	},
+}
+
+var deleteUsersSummaryView = render.View{
+	Text: `{{range .Users}}{{if .Deleted}}{{.Username}} - deleted{{with .AssetsMovedTo}}, assets moved to {{.}}{{end}}` +
+		`{{else if .Error}}{{.Username}} - failed: {{.Error}}{{else}}{{.Username}} - does not exist{{end}}` + "\n{{end}}" +
+		"\nDeleted: {{.Deleted}}\nNot found: {{.NotFound}}\nError: {{.Errored}}",
+	Columns: deleteUserView.Columns,
+}
+
+var updateUsersSummaryView = render.View{
+	Text: "\nAlready same role: {{.AlreadySame}}\nUpdated: {{.Updated}}\nNot found: {{.NotFound}}\n" +
+		"Error: {{.Errored}}",
+	Columns: []render.Column{
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "Site Role", Value: "{{.Role}}"},
+		{Header: "Previous Site Role", Value: "{{.PreviousRole}}"},
+		{Header: "Result", Value: "{{.Result}}"},
+		{Header: "Error", Value: "{{.Error}}"},
+	},
+}
+
+var usersPlanView = render.View{
+	Text: `{{range .Steps}}{{if eq .Action "create"}}+ create {{.Username}} as {{.Role}}` +
+		`{{else if eq .Action "update"}}~ update {{.Username}} from {{.PreviousRole}} to {{.Role}}` +
+		`{{with .Group}} required by group {{.}}{{end}}` +
+		`{{else}}- delete {{.Username}} ({{.PreviousRole}}), move assets to {{.AssetsMovedTo}}{{end}}` +
+		`{{with .Result}} - {{.}}{{end}}{{with .Error}}: {{.}}{{end}}` + "\n{{end}}" +
+		"\nCreate: {{.Create}}\nUpdate: {{.Update}}\nDelete: {{.Delete}}\nUnchanged: {{.Unchanged}}" +
+		"{{if .Applied}}\nError: {{.Errored}}{{end}}",
+	Columns: []render.Column{
+		{Header: "Action", Value: "{{.Action}}"},
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Site Role", Value: "{{.Role}}"},
+		{Header: "Previous Site Role", Value: "{{.PreviousRole}}"},
+		{Header: "Assets Moved To", Value: "{{.AssetsMovedTo}}"},
+		{Header: "Group", Value: "{{.Group}}"},
+		{Header: "Result", Value: "{{.Result}}"},
+		{Header: "Error", Value: "{{.Error}}"},
+	},
+}
+
+var groupView = render.View{
+	Text: `{{if .Exists}}{{.Name}} ({{.ID}}) - {{.Domain}}{{with .MinimumSiteRole}} - minimum site role {{.}}{{end}}` +
+		`{{with .GrantLicenseMode}} (granted {{.}}){{end}}{{else}}Group {{.Name}} does not exist!{{end}}`,
+	Columns: []render.Column{
+		{Header: "Name", Value: "{{.Name}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Domain", Value: "{{.Domain}}"},
+		{Header: "Minimum Site Role", Value: "{{.MinimumSiteRole}}"},
+		{Header: "Grant License Mode", Value: "{{.GrantLicenseMode}}"},
+	},
+}
+
+var createGroupView = render.View{
+	Text: `{{if .Created}}Group {{.Name}} created with ID {{.ID}}{{else}}Group {{.Name}} already exists!{{end}}`,
+	Columns: append(groupView.Columns[:len(groupView.Columns):len(groupView.Columns)],
+		render.Column{Header: "Created", Value: "{{.Created}}"}),
+}
+
+var deleteGroupView = render.View{
+	Text: `{{if .Deleted}}Group {{.Name}} deleted from the server{{else}}Group {{.Name}} does not exist - nothing to ` +
+		`delete!{{end}}`,
+	Columns: []render.Column{
+		{Header: "Name", Value: "{{.Name}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Deleted", Value: "{{.Deleted}}"},
+	},
+}
+
+var projectView = render.View{
+	Text: `{{if .Exists}}{{.Path}} ({{.ID}}){{with .ContentPermissions}} - {{.}}{{end}}` +
+		`{{with .Description}} - {{.}}{{end}}{{else}}Project {{.Path}} does not exist!{{end}}`,
+	Columns: []render.Column{
+		{Header: "Path", Value: "{{.Path}}"},
+		{Header: "Name", Value: "{{.Name}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Parent ID", Value: "{{.ParentID}}"},
+		{Header: "Content Permissions", Value: "{{.ContentPermissions}}"},
+		{Header: "Description", Value: "{{.Description}}"},
+	},
+}
+
+var projectTreeView = render.View{
+	Text:    `{{.Indent}}{{.Name}}{{with .ContentPermissions}} ({{.}}){{end}}`,
+	Columns: projectView.Columns,
+}
+
+var createProjectView = render.View{
+	Text: `{{if .Created}}Project {{.Path}} created with ID {{.ID}}{{else}}Project {{.Path}} already exists!{{end}}`,
+	Columns: append(projectView.Columns[:len(projectView.Columns):len(projectView.Columns)],
+		render.Column{Header: "Created", Value: "{{.Created}}"}),
+}
+
+var deleteProjectView = render.View{
+	Text: `{{if .Deleted}}Project {{.Path}} deleted from the server{{with .NestedProjects}}, with {{.}} nested ` +
+		`project(s){{end}}{{else}}Project {{.Path}} does not exist - nothing to delete!{{end}}`,
+	Columns: []render.Column{
+		{Header: "Path", Value: "{{.Path}}"},
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Deleted", Value: "{{.Deleted}}"},
+		{Header: "Nested Projects", Value: "{{.NestedProjects}}"},
+	},
+}
+
+var groupMembersSummaryView = render.View{
+	Text: `{{range .Users}}{{.Username}} - {{.Result}}{{with .Error}}: {{.}}{{end}}` + "\n{{end}}" +
+		"\nGroup: {{.Group}}\nAdded: {{.Added}}\nRemoved: {{.Removed}}\nAlready same: {{.Unchanged}}\n" +
+		"Not found: {{.NotFound}}\nError: {{.Errored}}",
+	Columns: []render.Column{
+		{Header: "Username", Value: "{{.Username}}"},
+		{Header: "Result", Value: "{{.Result}}"},
+		{Header: "Error", Value: "{{.Error}}"},
+	},
+}
+
+var jobView = render.View{
+	Text: `Job {{.ID}} ({{.Type}}) {{if not .CompletedAt}}is {{.Progress}}% done` +
+		`{{with .CreatedAt}}, created at {{.}}{{end}}{{else if eq .FinishCode 0}}succeeded at {{.CompletedAt}}` +
+		`{{else if eq .FinishCode 2}}was cancelled at {{.CompletedAt}}{{else}}failed at {{.CompletedAt}}{{end}}` +
+		`{{with .Notes}}: {{.}}{{end}}`,
+	Columns: []render.Column{
+		{Header: "ID", Value: "{{.ID}}"},
+		{Header: "Type", Value: "{{.Type}}"},
+		{Header: "Progress", Value: "{{.Progress}}"},
+		{Header: "Created At", Value: "{{.CreatedAt}}"},
+		{Header: "Started At", Value: "{{.StartedAt}}"},
+		{Header: "Completed At", Value: "{{.CompletedAt}}"},
+		{Header: "Finish Code", Value: "{{.FinishCode}}"},
+		{Header: "Notes", Value: "{{.Notes}}"},
+	},
+}
+
+var sessionView = render.View{
+	Text: `Successfully logged into {{.BaseURL}} (site ID {{.SiteID}}, user ID {{.UserID}}); token is {{.Token}}, ` +
+		`expires at {{.ExpiresAt.Format "2006-01-02T15:04:05Z07:00"}}`,
+	Columns: []render.Column{
+		{Header: "URL", Value: "{{.BaseURL}}"},
+		{Header: "Site", Value: "{{.Site}}"},
+		{Header: "Site ID", Value: "{{.SiteID}}"},
+		{Header: "User ID", Value: "{{.UserID}}"},
+		{Header: "Token", Value: "{{.Token}}"},
+		{Header: "Expires At", Value: `{{.ExpiresAt.Format "2006-01-02T15:04:05Z07:00"}}`},
+	},
+}
+
+var logoutView = render.View{
+	Text: `{{if .LoggedOut}}Logged out of {{.BaseURL}} (site ID {{.SiteID}}){{else}}No cached session - nothing to ` +
+		`log out from.{{end}}`,
+	Columns: []render.Column{
+		{Header: "URL", Value: "{{.BaseURL}}"},
+		{Header: "Site ID", Value: "{{.SiteID}}"},
+		{Header: "Logged Out", Value: "{{.LoggedOut}}"},
+	},
+}
+
+// validateOutput exits if the output format is not supported.
+func validateOutput() {
+	if err := render.Validate(outputFlag); err != nil {
+		log.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+// printOutput prints v in the format selected by output flag. Exits on failure.
+func printOutput(v interface{}, view render.View) {
+	if err := render.Render(os.Stdout, outputFlag, v, view); err != nil {
+		log.Errorf("Failed to print output: %s", err)
+		os.Exit(1)
+	}
+}
+
+// streamOutput prints items of the iterator as they're fetched, in the format selected by output flag, which must be
+// one that render.Streams. Exits on failure, including failure to fetch the items.
+func streamOutput[T any](items *internal.Iterator[T], view render.View) {
+	defer items.Close()
+
+	err := render.Stream(os.Stdout, outputFlag, func() (interface{}, bool) {
+		if !items.Next() {
+			return nil, false
+		}
+		return items.Value(), true
+	}, view)
+	if err != nil {
+		log.Errorf("Failed to print output: %s", err)
+		os.Exit(1)
+	}
+	if err := items.Err(); err != nil {
+		log.Errorf("Command failed: %s", err)
+		os.Exit(1)
+	}
+}
@@ -26,8 +26,10 @@ THE SOFTWARE.
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/davidlukac/go-tableau-cli/internal"
+	"github.com/davidlukac/go-tableau-cli/internal/render"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
@@ -36,12 +38,24 @@ import (
 
 // rootCmd represents the base command when called without any subcommands
 var (
-	cfgFile    string
-	outputFlag string
-	rootCmd    = &cobra.Command{
-		Use:    "tableau-cli",
-		Short:  "Tableau Server CLI",
-		PreRun: internal.LoggingSetup,
+	cfgFile         string
+	outputFlag      string
+	siteFlag        string
+	tokenNameFlag   string
+	tokenSecretFlag string
+	dryRunFlag      bool
+	rootCmd         = &cobra.Command{
+		Use:   "tableau-cli",
+		Short: "Tableau Server CLI",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			internal.LoggingSetup(cmd, args)
+			validateOutput()
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if dryRunFlag {
+				log.Warnf("Dry run - no changes were sent to the server")
+			}
+		},
 	}
 )
 
@@ -59,7 +73,21 @@ func init() {
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.go-tableau-cli.yaml)")
 
-	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "text", "Output format")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", render.Text,
+		fmt.Sprintf("Output format: %s", strings.Join(render.Formats, ", ")))
+	rootCmd.PersistentFlags().StringVar(&siteFlag, "site", "",
+		fmt.Sprintf("Content URL of the site to sign in to; overrides %s, empty for the Default site", internal.SiteVar))
+	_ = viper.BindPFlag(strings.ToLower(internal.SiteVar), rootCmd.PersistentFlags().Lookup("site"))
+	rootCmd.PersistentFlags().StringVar(&tokenNameFlag, "token-name", "",
+		fmt.Sprintf("Name of personal access token to sign in with instead of password; overrides %s",
+			internal.TokenNameVar))
+	_ = viper.BindPFlag(strings.ToLower(internal.TokenNameVar), rootCmd.PersistentFlags().Lookup("token-name"))
+	rootCmd.PersistentFlags().StringVar(&tokenSecretFlag, "token-secret", "",
+		fmt.Sprintf("Secret of personal access token; overrides %s", internal.TokenSecretVar))
+	_ = viper.BindPFlag(strings.ToLower(internal.TokenSecretVar), rootCmd.PersistentFlags().Lookup("token-secret"))
+
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false,
+		"Look up everything, but only print requests which would make changes instead of sending them")
 
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
@@ -87,6 +115,30 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		_, _ = fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	internal.SetRetryPolicy(internal.LoadRetryPolicy())
+}
+
+// signIn returns client for the configured server and site, reusing cached session if possible. Exits on failure.
+func signIn() *internal.Tableau {
+	url := viper.GetString("tableau_url")
+	site := viper.GetString(strings.ToLower(internal.SiteVar))
+
+	sessionFile, err := internal.SessionFilePath()
+	if err != nil {
+		log.Errorf("Failed to log in: %s", err)
+		os.Exit(1)
+	}
+
+	t, err := internal.NewTableau(url, internal.LoadCredentials(), site, sessionFile)
+	if err != nil {
+		log.Errorf("Failed to log in: %s", err)
+		os.Exit(1)
+	}
+	t.Concurrency = viper.GetInt(strings.ToLower(internal.ConcurrencyVar))
+	t.DryRun = dryRunFlag
+
+	return t
 }
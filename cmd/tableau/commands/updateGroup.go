@@ -0,0 +1,308 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	NameFlagName        = "name"
+	AddUsersFlagName    = "add-users"
+	RemoveUsersFlagName = "remove-users"
+	SyncFlagName        = "sync"
+	WaitFlagName        = "wait"
+	WaitTimeoutFlagName = "wait-timeout"
+)
+
+var (
+	updateGroupNameFlag            string
+	updateGroupMinimumSiteRoleFlag string
+	updateGroupAddUsersFlag        []string
+	updateGroupRemoveUsersFlag     []string
+	updateGroupFromFileFlag        string
+	updateGroupSyncFlag            bool
+	updateGroupWaitFlag            bool
+	updateGroupWaitTimeoutFlag     time.Duration
+	updateGroupGrantLicenseMode    string
+)
+
+// updateGroupCmd represents the update group command
+var updateGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Rename group, change its minimum site role, add and remove its members, or synchronize it",
+	Long: fmt.Sprintf(`
+Rename group or change its minimum site role, or add and remove members by username, e.g.
+
+  update group Finance --%s john.smith,jane.doe --%s bob.x
+
+Users to add can be also listed in YAML or CSV file given by --%s flag, in the same format as for create user.
+Members are changed before the group is renamed.
+
+Group imported from Active Directory is synchronized with --%s flag, which starts synchronization job on the server
+and prints it; with --%s flag the command waits until the job completes, at most for --%s. Minimum site role and
+grant license mode of the group can be changed at the same time.
+`, AddUsersFlagName, RemoveUsersFlagName, FromFileFlagName, SyncFlagName, WaitFlagName, WaitTimeoutFlagName),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		toAdd := updateGroupAddUsersFlag
+		if updateGroupFromFileFlag != "" {
+			users, err := internal.ReadUsersFile(updateGroupFromFileFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			for _, user := range users {
+				toAdd = append(toAdd, user.Username)
+			}
+		}
+		membersChanged := len(toAdd) > 0 || len(updateGroupRemoveUsersFlag) > 0
+
+		adding := make(map[string]bool, len(toAdd))
+		for _, username := range toAdd {
+			adding[strings.ToLower(username)] = true
+		}
+		for _, username := range updateGroupRemoveUsersFlag {
+			if adding[strings.ToLower(username)] {
+				log.Errorf("Invalid arguments: user %s is listed both to add and to remove", username)
+				os.Exit(1)
+			}
+		}
+
+		if updateGroupSyncFlag && (updateGroupNameFlag != "" || membersChanged) {
+			log.Errorf("Invalid arguments: --%s can't be combined with renaming the group or changing its members",
+				SyncFlagName)
+			os.Exit(1)
+		}
+		if !updateGroupSyncFlag && (updateGroupWaitFlag || updateGroupGrantLicenseMode != "") {
+			log.Errorf("Invalid arguments: --%s and --%s can be set only with --%s", WaitFlagName,
+				GrantLicenseModeFlagName, SyncFlagName)
+			os.Exit(1)
+		}
+		if cmd.Flags().Changed(WaitTimeoutFlagName) && !updateGroupWaitFlag {
+			log.Errorf("Invalid arguments: --%s can be set only with --%s", WaitTimeoutFlagName, WaitFlagName)
+			os.Exit(1)
+		}
+		if updateGroupWaitTimeoutFlag <= 0 {
+			log.Errorf("Invalid arguments: --%s must be positive", WaitTimeoutFlagName)
+			os.Exit(1)
+		}
+		if updateGroupNameFlag == "" && updateGroupMinimumSiteRoleFlag == "" && !membersChanged &&
+			!updateGroupSyncFlag {
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+		if updateGroupGrantLicenseMode != "" {
+			mode, err := internal.ValidateGrantLicenseMode(updateGroupGrantLicenseMode)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			updateGroupGrantLicenseMode = mode
+		}
+		if updateGroupMinimumSiteRoleFlag != "" {
+			role, err := internal.ValidateSiteRole(updateGroupMinimumSiteRoleFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			updateGroupMinimumSiteRoleFlag = role
+		}
+
+		t := signIn()
+
+		group, err := t.GetGroup(name)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		if !group.Exists {
+			group.Name = name
+			printOutput(group, groupView)
+			os.Exit(1)
+		}
+
+		if updateGroupSyncFlag {
+			syncGroup(t, group)
+			return
+		}
+
+		if membersChanged {
+			summary := updateGroupMembers(t, group, toAdd, updateGroupRemoveUsersFlag)
+			if updateGroupNameFlag == "" && updateGroupMinimumSiteRoleFlag == "" {
+				printOutput(summary, groupMembersSummaryView)
+				if summary.Errored > 0 {
+					os.Exit(1)
+				}
+				return
+			}
+			if summary.Errored > 0 {
+				printOutput(summary, groupMembersSummaryView)
+				log.Errorf("Command failed: group %s was not updated, because some members failed to change", name)
+				os.Exit(1)
+			}
+			log.Infof("Added %d and removed %d members of group %s", summary.Added, summary.Removed, name)
+		}
+
+		group, err = t.UpdateGroup(internal.Group{ID: group.ID, Name: updateGroupNameFlag,
+			MinimumSiteRole: updateGroupMinimumSiteRoleFlag})
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+
+		printOutput(group, groupView)
+	},
+}
+
+// syncGroup synchronizes group imported from Active Directory, setting minimum site role and grant license mode given
+// by flags, and prints the job - waits for it to complete if requested.
+func syncGroup(t *internal.Tableau, group *internal.Group) {
+	if updateGroupMinimumSiteRoleFlag != "" {
+		group.MinimumSiteRole = updateGroupMinimumSiteRoleFlag
+	}
+	if updateGroupGrantLicenseMode != "" {
+		group.GrantLicenseMode = updateGroupGrantLicenseMode
+	}
+
+	job, err := t.SyncGroup(*group)
+	if err != nil {
+		log.Errorf("Command failed: %s", err)
+		os.Exit(1)
+	}
+	log.Infof("Synchronization of group %s started as job %s", group.Name, job.ID)
+
+	if updateGroupWaitFlag && !t.DryRun {
+		if job, err = t.WaitForJob(job.ID, updateGroupWaitTimeoutFlag); err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	printOutput(job, jobView)
+
+	if job.CompletedAt != "" && job.FinishCode != 0 {
+		os.Exit(1)
+	}
+}
+
+// updateGroupMembers adds and removes members of the group by username, and returns summary of the changes. Usernames
+// are case-insensitive, and users listed more than once are changed only once.
+func updateGroupMembers(t *internal.Tableau, group *internal.Group, toAdd, toRemove []string) GroupMembersSummary {
+	summary := GroupMembersSummary{Group: group.Name, Users: make([]GroupMemberResult, 0, len(toAdd)+len(toRemove))}
+
+	seen := make(map[string]bool)
+	for i, username := range append(append([]string{}, toAdd...), toRemove...) {
+		if seen[strings.ToLower(username)] {
+			continue
+		}
+		seen[strings.ToLower(username)] = true
+
+		result := updateGroupMember(t, group, username, i < len(toAdd))
+		switch result.Result {
+		case MemberResultAdded:
+			summary.Added++
+		case MemberResultRemoved:
+			summary.Removed++
+		case MemberResultSame:
+			summary.Unchanged++
+		case MemberResultNotFound:
+			summary.NotFound++
+		default:
+			summary.Errored++
+		}
+		summary.Users = append(summary.Users, result)
+	}
+
+	return summary
+}
+
+// updateGroupMember adds user to the group, or removes it if add is false. Adding existing member and removing user who
+// isn't member is not an error.
+func updateGroupMember(t *internal.Tableau, group *internal.Group, username string, add bool) GroupMemberResult {
+	result := GroupMemberResult{Username: username}
+
+	user, err := t.GetUser(username)
+	if err == nil && !user.Exists {
+		log.Warnf("User %s does not exist - skipping", username)
+		result.Result = MemberResultNotFound
+		return result
+	}
+
+	if err == nil && add {
+		err = t.AddUserToGroup(group.ID, user.ID)
+		if internal.HasErrorCode(err, internal.MemberExistsErrorCode) {
+			result.Result = MemberResultSame
+			return result
+		}
+		result.Result = MemberResultAdded
+	} else if err == nil {
+		err = t.RemoveUserFromGroup(group.ID, user.ID)
+		if internal.HasErrorCode(err, internal.MemberNotFoundErrorCode) {
+			result.Result = MemberResultSame
+			return result
+		}
+		result.Result = MemberResultRemoved
+	}
+
+	if err != nil {
+		log.Errorf("Failed to change membership of user %s in group %s: %s", username, group.Name, err)
+		result.Result = MemberResultError
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func init() {
+	updateCmd.AddCommand(updateGroupCmd)
+
+	updateGroupCmd.Flags().StringVar(&updateGroupNameFlag, NameFlagName, "", "New name of the group")
+	updateGroupCmd.Flags().StringVar(&updateGroupMinimumSiteRoleFlag, MinimumSiteRoleFlagName, "",
+		fmt.Sprintf("Site role users get when they're added to the group, one of %s",
+			strings.Join(internal.SiteRoles, ", ")))
+	updateGroupCmd.Flags().StringSliceVar(&updateGroupAddUsersFlag, AddUsersFlagName, nil,
+		"Usernames of users to add to the group")
+	updateGroupCmd.Flags().StringSliceVar(&updateGroupRemoveUsersFlag, RemoveUsersFlagName, nil,
+		"Usernames of users to remove from the group")
+	updateGroupCmd.Flags().StringVar(&updateGroupFromFileFlag, FromFileFlagName, "",
+		"Path to YAML or CSV file with users to add to the group")
+	updateGroupCmd.Flags().BoolVar(&updateGroupSyncFlag, SyncFlagName, false,
+		"Synchronize group imported from Active Directory")
+	updateGroupCmd.Flags().BoolVar(&updateGroupWaitFlag, WaitFlagName, false,
+		"Wait until synchronization of the group completes")
+	updateGroupCmd.Flags().DurationVar(&updateGroupWaitTimeoutFlag, WaitTimeoutFlagName, internal.DefaultJobWaitTimeout,
+		"How long to wait for synchronization of the group to complete")
+	updateGroupCmd.Flags().StringVar(&updateGroupGrantLicenseMode, GrantLicenseModeFlagName, "",
+		fmt.Sprintf("When members of synchronized group get the minimum site role, one of %s",
+			strings.Join(internal.GrantLicenseModes, ", ")))
+}
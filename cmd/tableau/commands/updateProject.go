@@ -0,0 +1,149 @@
+package commands
+
+/*
+Copyright © 2022 David Lukac <1215290+davidlukac@users.noreply.github.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const ParentFlagName = "parent"
+
+var (
+	updateProjectNameFlag               string
+	updateProjectDescriptionFlag        string
+	updateProjectContentPermissionsFlag string
+	updateProjectParentFlag             string
+)
+
+// updateProjectCmd represents the update project command
+var updateProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Rename project, move it to another parent project, or change its description or content permissions",
+	Long: fmt.Sprintf(`
+Update project given by path - names of parent projects and the project separated by /, e.g.
+
+  update project Finance/Reports --%s Archive --%s "Reports of past years" --%s ManagedByOwner
+
+Use --%s / to move the project to the top level. The project can't be moved under itself or its nested projects.
+`, ParentFlagName, DescriptionFlagName, ContentPermissionsFlagName, ParentFlagName),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+
+		if updateProjectNameFlag == "" && updateProjectDescriptionFlag == "" &&
+			updateProjectContentPermissionsFlag == "" && updateProjectParentFlag == "" {
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+		if updateProjectContentPermissionsFlag != "" {
+			mode, err := internal.ValidateContentPermissions(updateProjectContentPermissionsFlag)
+			if err != nil {
+				log.Errorf("Invalid arguments: %s", err)
+				os.Exit(1)
+			}
+			updateProjectContentPermissionsFlag = mode
+		}
+
+		t := signIn()
+
+		tree, err := t.GetProjectTree()
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		project := internal.FindProject(tree, path)
+		if project == nil {
+			printOutput(&internal.Project{Path: path}, projectView)
+			os.Exit(1)
+		}
+
+		update := internal.Project{ID: project.ID, Name: updateProjectNameFlag,
+			Description: updateProjectDescriptionFlag, ContentPermissions: updateProjectContentPermissionsFlag}
+		if updateProjectParentFlag != "" && strings.Trim(updateProjectParentFlag, internal.ProjectPathSeparator) == "" {
+			update.MoveToTopLevel = true
+		} else if updateProjectParentFlag != "" {
+			parent := internal.FindProject(tree, updateProjectParentFlag)
+			if parent == nil {
+				log.Errorf("Command failed: parent project %s does not exist", updateProjectParentFlag)
+				os.Exit(1)
+			}
+			for _, p := range internal.SubTree(tree, project) {
+				if p == parent {
+					log.Errorf("Command failed: project %s can't be moved under itself or its nested project %s",
+						project.Path, parent.Path)
+					os.Exit(1)
+				}
+			}
+			update.ParentID = parent.ID
+		}
+
+		updated, err := t.UpdateProject(update)
+		if err != nil {
+			log.Errorf("Command failed: %s", err)
+			os.Exit(1)
+		}
+		if dryRunFlag {
+			updated = project
+			if update.Name != "" {
+				updated.Name = update.Name
+			}
+			if update.Description != "" {
+				updated.Description = update.Description
+			}
+			if update.ContentPermissions != "" {
+				updated.ContentPermissions = update.ContentPermissions
+			}
+			if update.ParentID != "" || update.MoveToTopLevel {
+				updated.ParentID = update.ParentID
+			}
+		}
+
+		updated.Path, updated.Level = updated.Name, 0
+		for _, parent := range tree {
+			if parent.ID == updated.ParentID {
+				updated.Path = parent.Path + internal.ProjectPathSeparator + updated.Name
+				updated.Level = parent.Level + 1
+			}
+		}
+
+		printOutput(updated, projectView)
+	},
+}
+
+func init() {
+	updateCmd.AddCommand(updateProjectCmd)
+
+	updateProjectCmd.Flags().StringVar(&updateProjectNameFlag, NameFlagName, "", "New name of the project")
+	updateProjectCmd.Flags().StringVar(&updateProjectDescriptionFlag, DescriptionFlagName, "",
+		"Description of the project")
+	updateProjectCmd.Flags().StringVar(&updateProjectContentPermissionsFlag, ContentPermissionsFlagName, "",
+		fmt.Sprintf("Content permissions mode, one of %s", strings.Join(internal.ContentPermissions, ", ")))
+	updateProjectCmd.Flags().StringVar(&updateProjectParentFlag, ParentFlagName, "",
+		"Path of parent project to move the project to, or / to move it to the top level")
+}
@@ -27,20 +27,25 @@ import (
 	"github.com/davidlukac/go-tableau-cli/internal"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 	"os"
 	"strings"
 )
 
 const (
-	SiteRoleFlagName = "site-role"
-	FromYamlFlagName = "from-yaml"
+	SiteRoleFlagName    = "site-role"
+	AuthSettingFlagName = "auth-setting"
+	FromYamlFlagName    = "from-yaml"
+	FromFileFlagName    = "from-file"
+	EmailFlagName       = "email"
+	FullNameFlagName    = "full-name"
 )
 
 var (
 	siteRoleFlag string
 	fromYamlFlag string
+	emailFlag    string
+	fullNameFlag string
 )
 
 // updateUserCmd represents the updateUser command
@@ -48,40 +53,64 @@ var updateUserCmd = &cobra.Command{
 	Use:   "user",
 	Short: "Update existing user properties",
 	Long: fmt.Sprintf(`
-Update can be done with provided username argument and %s flag, or
+Update can be done with provided username argument and %s, %s and/or %s flags, or
 with list of users and roles in YAML file with usernames and roles formatted as
 - username: john.smith
   role: Explorer
-`, SiteRoleFlagName),
-	PreRun: internal.LoggingSetup,
-	Args:   cobra.MinimumNArgs(0),
+`, SiteRoleFlagName, EmailFlagName, FullNameFlagName),
+	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) > 0 && len(siteRoleFlag) > 0 {
-			// Updating one user with given role
+		if len(args) > 0 && (len(siteRoleFlag) > 0 || len(emailFlag) > 0 || len(fullNameFlag) > 0) {
+			// Updating one user with given properties
 			username := args[0]
 
-			url := viper.GetString("tableau_url")
-			apiUsername := viper.GetString("tableau_username")
-			apiPassword := viper.GetString("tableau_password")
-			token, siteId, err := internal.Login(url, apiUsername, apiPassword)
+			t := signIn()
+
+			user, err := t.GetUser(username)
 			if err != nil {
-				log.Errorf("Failed to log in: %s", err)
+				log.Errorf("Command failed: %s", err)
+				os.Exit(1)
+			}
+			if !user.Exists {
+				user.Username = username
+				printOutput(user, userView)
 				os.Exit(1)
 			}
 
-			t := internal.Tableau{
-				BaseURL: url,
-				Token:   token,
-				SiteID:  siteId,
+			if len(siteRoleFlag) > 0 {
+				if _, err := t.UpdateUserSiteRole(username, siteRoleFlag); err != nil {
+					log.Errorf("Command failed: %s", err)
+					os.Exit(1)
+				}
 			}
 
-			user, err := t.UpdateUserSiteRole(username, siteRoleFlag)
-			if err != nil {
-				log.Errorf("Command failed: %s", err)
-				os.Exit(1)
+			if len(emailFlag) > 0 || len(fullNameFlag) > 0 {
+				_, err := t.UpdateUser(internal.User{ID: user.ID, Email: emailFlag, FullName: fullNameFlag})
+				if err != nil {
+					log.Errorf("Command failed: %s", err)
+					os.Exit(1)
+				}
 			}
 
-			fmt.Printf("%s (%s) - %s\n", user.Username, user.ID, user.Role)
+			if dryRunFlag {
+				if len(siteRoleFlag) > 0 {
+					user.Role = siteRoleFlag
+				}
+				if len(emailFlag) > 0 {
+					user.Email = emailFlag
+				}
+				if len(fullNameFlag) > 0 {
+					user.FullName = fullNameFlag
+				}
+			} else {
+				user, err = t.GetUser(username)
+				if err != nil {
+					log.Errorf("Command failed: %s", err)
+					os.Exit(1)
+				}
+			}
+
+			printOutput(user, userView)
 		} else if len(args) == 0 && len(fromYamlFlag) > 0 {
 			fileInfo, err := os.Stat(fromYamlFlag)
 			if os.IsNotExist(err) {
@@ -107,54 +136,51 @@ with list of users and roles in YAML file with usernames and roles formatted as
 
 			log.Debugf("Loaded %d user from file", len(users))
 
-			url := viper.GetString("tableau_url")
-			apiUsername := viper.GetString("tableau_username")
-			apiPassword := viper.GetString("tableau_password")
-			token, siteId, err := internal.Login(url, apiUsername, apiPassword)
-			if err != nil {
-				log.Errorf("Failed to log in: %s", err)
-				os.Exit(1)
-			}
-
-			t := internal.Tableau{
-				BaseURL: url,
-				Token:   token,
-				SiteID:  siteId,
-			}
+			t := signIn()
 
-			alreadySame := 0
-			updated := 0
-			notExists := 0
-			errored := 0
+			summary := UpdateUsersSummary{Users: make([]UpdateUserResult, 0, len(users))}
 
 			for idx, user := range users {
+				result := UpdateUserResult{Username: user.Username, Role: user.Role}
+
 				actual, err := t.GetUser(user.Username)
 				if err != nil {
-					errored++
+					summary.Errored++
+					result.Result = UpdateResultError
+					result.Error = err.Error()
 					log.Errorf("[%d/%d] Failed to fetch user %s - skipping update", idx, len(users), user.Username)
 				} else {
+					result.PreviousRole = actual.Role
 					if actual.Exists {
 						if strings.EqualFold(actual.Role, user.Role) {
-							alreadySame++
+							summary.AlreadySame++
+							result.Result = UpdateResultSame
 							log.Infof("[%d/%d] User %s already has role %s", idx, len(users), user.Username, actual.Role)
 						} else {
 							log.Infof("[%d/%d] Updating user %s from role %s to role %s...", idx, len(users), user.Username, actual.Role, user.Role)
 							updateUser, err := t.UpdateUserSiteRole(user.Username, user.Role)
 							if err != nil {
-								errored++
+								summary.Errored++
+								result.Result = UpdateResultError
+								result.Error = err.Error()
 								log.Errorf("Failed to update user %s to role %s: %v", user.Username, user.Role, err)
 							} else {
-								updated++
+								summary.Updated++
+								result.Result = UpdateResultUpdated
 								log.Infof("... user %s update to role %s", user.Username, updateUser.Role)
 							}
 						}
 					} else {
-						notExists++
+						summary.NotFound++
+						result.Result = UpdateResultNotFound
 						log.Warnf("[%d/%d] User %s doesn't not existing! Skipping...", idx, len(users), user.Username)
 					}
 				}
+
+				summary.Users = append(summary.Users, result)
 			}
-			fmt.Printf("\nAlready same role: %d\nUpdated: %d\nNot found: %d\nError: %d\n", alreadySame, updated, notExists, errored)
+
+			printOutput(summary, updateUsersSummaryView)
 
 		} else {
 			_ = cmd.Help()
@@ -166,5 +192,7 @@ func init() {
 	updateCmd.AddCommand(updateUserCmd)
 
 	updateUserCmd.Flags().StringVar(&siteRoleFlag, SiteRoleFlagName, "", "User's role")
+	updateUserCmd.Flags().StringVar(&emailFlag, EmailFlagName, "", "User's email")
+	updateUserCmd.Flags().StringVar(&fullNameFlag, FullNameFlagName, "", "User's full name")
 	updateUserCmd.Flags().StringVar(&fromYamlFlag, FromYamlFlagName, "", "Path to YAML file with username(s) and role(s)")
 }
@@ -3,7 +3,6 @@ module github.com/davidlukac/go-tableau-cli
 go 1.19
 
 require (
-	github.com/fastbill/go-httperrors v1.0.0
 	github.com/sirupsen/logrus v1.9.0
 	github.com/spf13/cobra v1.6.1
 	github.com/spf13/viper v1.13.0
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+)
+
+// Actions of UsersPlan steps.
+const (
+	PlanCreate = "create"
+	PlanUpdate = "update"
+	PlanDelete = "delete"
+)
+
+// Results of applied UsersPlan steps.
+const (
+	PlanResultDone  = "done"
+	PlanResultError = "error"
+)
+
+// UsersPlanStep is one change of users on the site.
+type UsersPlanStep struct {
+	Action       string `json:"action" yaml:"action"`
+	Username     string `json:"username" yaml:"username"`
+	ID           string `json:"id,omitempty" yaml:"id,omitempty"`
+	Role         string `json:"role,omitempty" yaml:"role,omitempty"`
+	PreviousRole string `json:"previousRole,omitempty" yaml:"previousRole,omitempty"`
+	AuthSetting  string `json:"authSetting,omitempty" yaml:"authSetting,omitempty"`
+	Email        string `json:"email,omitempty" yaml:"email,omitempty"`
+	FullName     string `json:"fullName,omitempty" yaml:"fullName,omitempty"`
+	// AssetsMovedTo is username of the user who takes over assets of deleted user.
+	AssetsMovedTo string `json:"assetsMovedTo,omitempty" yaml:"assetsMovedTo,omitempty"`
+	// Group which requires the role, in plans of group roles - see PlanGroupRoles.
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	// Result is set once the step is applied.
+	Result string `json:"result,omitempty" yaml:"result,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// UsersPlan lists changes which make users on the site match desired users: creates missing users, updates site roles
+// and, if pruning, deletes users which are not desired.
+type UsersPlan struct {
+	Create    int             `json:"create" yaml:"create"`
+	Update    int             `json:"update" yaml:"update"`
+	Delete    int             `json:"delete" yaml:"delete"`
+	Unchanged int             `json:"unchanged" yaml:"unchanged"`
+	Applied   bool            `json:"applied" yaml:"applied"`
+	Errored   int             `json:"errored" yaml:"errored"`
+	Steps     []UsersPlanStep `json:"steps" yaml:"steps"`
+
+	assetsUserID string
+}
+
+func (p *UsersPlan) Items() interface{} {
+	return p.Steps
+}
+
+// PlanUsers compares desired users with users on the site and returns plan of changes. Usernames are matched
+// case-insensitively. Desired users without role are created with DefaultRole and their role is not changed.
+// When prune is true, users which are not desired are deleted and their assets moved to user assetsUsername, which
+// must exist; neither that user nor the signed-in user is ever deleted.
+// Returns non-nil error if desired users are not valid, or fails to get users from the server.
+func (t *Tableau) PlanUsers(desired []*User, prune bool, assetsUsername string) (*UsersPlan, error) {
+	wanted := make(map[string]*User, len(desired))
+	for _, user := range desired {
+		key := strings.ToLower(user.Username)
+		if _, ok := wanted[key]; ok {
+			return nil, fmt.Errorf("user %s is listed more than once", user.Username)
+		}
+		wanted[key] = user
+
+		var err error
+		if user.Role != "" {
+			if user.Role, err = ValidateSiteRole(user.Role); err != nil {
+				return nil, fmt.Errorf("user %s: %w", user.Username, err)
+			}
+		}
+		if user.AuthSetting != "" {
+			if user.AuthSetting, err = ValidateAuthSetting(user.AuthSetting); err != nil {
+				return nil, fmt.Errorf("user %s: %w", user.Username, err)
+			}
+		}
+	}
+
+	actual, err := t.GetUsers(ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UsersPlan{Steps: make([]UsersPlanStep, 0)}
+	existing := make(map[string]*User, len(actual))
+	var assetsUser *User
+	for _, user := range actual {
+		existing[strings.ToLower(user.Username)] = user
+		if assetsUsername != "" && strings.EqualFold(user.Username, assetsUsername) {
+			assetsUser = user
+		}
+	}
+
+	if prune {
+		if assetsUsername == "" {
+			return nil, fmt.Errorf("user to move assets of deleted users to is required to delete users")
+		}
+		if assetsUser == nil {
+			return nil, fmt.Errorf("user %s to move assets of deleted users to does not exist", assetsUsername)
+		}
+		if !CanOwnContent(assetsUser.Role) {
+			return nil, fmt.Errorf("user %s to move assets of deleted users to has site role %s, which can't own "+
+				"content", assetsUsername, assetsUser.Role)
+		}
+		plan.assetsUserID = assetsUser.ID
+	}
+
+	var creates, updates, deletes []UsersPlanStep
+	for key, user := range wanted {
+		current, ok := existing[key]
+		switch {
+		case !ok:
+			role := user.Role
+			if role == "" {
+				role = DefaultRole
+			}
+			creates = append(creates, UsersPlanStep{Action: PlanCreate, Username: user.Username, Role: role,
+				AuthSetting: user.AuthSetting, Email: user.Email, FullName: user.FullName})
+		case user.Role != "" && !strings.EqualFold(user.Role, current.Role):
+			updates = append(updates, UsersPlanStep{Action: PlanUpdate, Username: current.Username, ID: current.ID,
+				Role: user.Role, PreviousRole: current.Role})
+		default:
+			plan.Unchanged++
+		}
+	}
+	if prune {
+		for key, user := range existing {
+			if _, ok := wanted[key]; ok || user.ID == t.UserID || user.ID == assetsUser.ID {
+				continue
+			}
+			deletes = append(deletes, UsersPlanStep{Action: PlanDelete, Username: user.Username, ID: user.ID,
+				PreviousRole: user.Role, AssetsMovedTo: assetsUser.Username})
+		}
+	}
+
+	for _, steps := range [][]UsersPlanStep{creates, updates, deletes} {
+		sort.Slice(steps, func(i, j int) bool {
+			return strings.ToLower(steps[i].Username) < strings.ToLower(steps[j].Username)
+		})
+		plan.Steps = append(plan.Steps, steps...)
+	}
+	plan.Create, plan.Update, plan.Delete = len(creates), len(updates), len(deletes)
+
+	return plan, nil
+}
+
+// ApplyUsers executes steps of the plan in order - creates, updates, deletes - and records result of every step.
+// Failed steps don't stop the rest; returns number of failed steps.
+func (t *Tableau) ApplyUsers(plan *UsersPlan) int {
+	plan.Applied = true
+
+	for idx := range plan.Steps {
+		step := &plan.Steps[idx]
+
+		var err error
+		switch step.Action {
+		case PlanCreate:
+			var user *User
+			user, err = t.CreateUser(User{Username: step.Username, Role: step.Role, AuthSetting: step.AuthSetting,
+				Email: step.Email, FullName: step.FullName})
+			if user != nil {
+				step.ID = user.ID
+			}
+		case PlanUpdate:
+			_, err = t.UpdateUser(User{ID: step.ID, Role: step.Role})
+		case PlanDelete:
+			_, err = t.DeleteUser(step.ID, plan.assetsUserID)
+		}
+
+		if err != nil {
+			plan.Errored++
+			step.Result = PlanResultError
+			step.Error = err.Error()
+			log.Errorf("[%d/%d] Failed to %s user %s: %s", idx+1, len(plan.Steps), step.Action, step.Username, err)
+		} else {
+			step.Result = PlanResultDone
+			log.Infof("[%d/%d] User %s: %s done", idx+1, len(plan.Steps), step.Username, step.Action)
+		}
+	}
+
+	return plan.Errored
+}
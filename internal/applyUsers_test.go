@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"reflect"
+	"testing"
+)
+
+func TestPlanUsers(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	site.AddUser(tableautest.User{Name: "john.smith", SiteRole: "Creator"})
+	site.AddUser(tableautest.User{Name: "jane.doe", SiteRole: "Viewer"})
+	site.AddUser(tableautest.User{Name: "bob.x", SiteRole: "Explorer"})
+
+	tests := []struct {
+		name      string
+		desired   []*User
+		prune     bool
+		assets    string
+		expected  []string
+		unchanged int
+		wantErr   bool
+	}{
+		{
+			name: "create, update and keep users",
+			desired: []*User{{Username: "John.Smith", Role: "creator"}, {Username: "jane.doe", Role: "explorer"},
+				{Username: "new.guy"}, {Username: "bob.x"}},
+			expected:  []string{"create new.guy Viewer", "update jane.doe Explorer"},
+			unchanged: 2,
+		},
+		{
+			name:     "create with given role and auth setting",
+			desired:  []*User{{Username: "new.guy", Role: "Creator", AuthSetting: "saml"}},
+			expected: []string{"create new.guy Creator"},
+		},
+		{
+			name:      "prune users which are not desired",
+			desired:   []*User{{Username: "jane.doe"}},
+			prune:     true,
+			assets:    "john.smith",
+			expected:  []string{"delete bob.x "},
+			unchanged: 1,
+		},
+		{
+			name:    "prune without user taking over assets",
+			desired: []*User{{Username: "jane.doe"}},
+			prune:   true,
+			wantErr: true,
+		},
+		{
+			name:    "prune with user taking over assets who doesn't exist",
+			desired: []*User{{Username: "jane.doe"}},
+			prune:   true,
+			assets:  "nobody",
+			wantErr: true,
+		},
+		{
+			name:    "prune with user taking over assets who can't own content",
+			desired: []*User{{Username: "john.smith"}},
+			prune:   true,
+			assets:  "jane.doe",
+			wantErr: true,
+		},
+		{
+			name:    "user listed twice",
+			desired: []*User{{Username: "jane.doe"}, {Username: "Jane.Doe"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid site role",
+			desired: []*User{{Username: "jane.doe", Role: "Boss"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid auth setting",
+			desired: []*User{{Username: "jane.doe", AuthSetting: "magic"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tableau.PlanUsers(tt.desired, tt.prune, tt.assets)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got plan %+v", plan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, step := range plan.Steps {
+				role := step.Role
+				if step.Action == PlanDelete {
+					role = ""
+					if step.AssetsMovedTo != tt.assets {
+						t.Errorf("assets of %s are moved to %s, expected %s", step.Username, step.AssetsMovedTo,
+							tt.assets)
+					}
+				}
+				got = append(got, fmt.Sprintf("%s %s %s", step.Action, step.Username, role))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got steps %q, expected %q", got, tt.expected)
+			}
+			if plan.Unchanged != tt.unchanged {
+				t.Errorf("got %d unchanged users, expected %d", plan.Unchanged, tt.unchanged)
+			}
+			if plan.Create+plan.Update+plan.Delete != len(plan.Steps) {
+				t.Errorf("counts %d/%d/%d don't match %d steps", plan.Create, plan.Update, plan.Delete,
+					len(plan.Steps))
+			}
+		})
+	}
+}
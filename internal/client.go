@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// httpClient is shared by all requests to the server, so that connections are reused between calls.
+var httpClient = &http.Client{
+	Transport: http.DefaultTransport.(*http.Transport).Clone(),
+	Timeout:   DefaultHTTPTimeout,
+}
+
+// siteURL returns URL of given site-scoped endpoint, e.g. siteURL("users/%s", userID).
+func (t *Tableau) siteURL(format string, args ...interface{}) string {
+	return fmt.Sprintf("%s/sites/%s/%s", t.BaseURL, t.SiteID, fmt.Sprintf(format, args...))
+}
+
+// do sends authenticated request to the server - see sendRequest.
+// If the session expired and Relogin is set, signs in again once and replays the request with the new token.
+// In dry run, requests other than GET are only printed and result is left as it is.
+func (t *Tableau) do(method, url string, payload, result interface{}, expectedStatus int) error {
+	if t.DryRun && method != http.MethodGet {
+		return printDryRun(method, url, payload)
+	}
+
+	token := t.token()
+
+	err := sendRequest(method, url, token, payload, result, expectedStatus)
+	if !errors.Is(err, ErrSessionExpired) || t.Relogin == nil {
+		return err
+	}
+
+	log.Infof("Session expired, logging in again")
+
+	if err := t.relogin(token); err != nil {
+		return fmt.Errorf("failed to renew expired session: %w", err)
+	}
+
+	return sendRequest(method, url, t.token(), payload, result, expectedStatus)
+}
+
+// concurrency returns maximum number of parallel requests when fetching pages of a list.
+func (t *Tableau) concurrency() int {
+	if t.Concurrency < 1 {
+		return DefaultConcurrency
+	}
+	return t.Concurrency
+}
+
+// printDryRun prints request which would be sent in dry run.
+func printDryRun(method, url string, payload interface{}) error {
+	body := ""
+	if payload != nil {
+		data, err := xml.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = " " + string(data)
+	}
+
+	_, err := fmt.Fprintf(os.Stderr, "[dry-run] %s %s%s\n", method, url, body)
+	return err
+}
+
+// token returns current authentication token.
+func (t *Tableau) token() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.Token
+}
+
+// relogin replaces expired token with a new one, unless it was already replaced by another request.
+func (t *Tableau) relogin(expiredToken string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Token != expiredToken {
+		return nil
+	}
+
+	session, err := t.Relogin()
+	if err != nil {
+		return err
+	}
+	if session.SiteID != t.SiteID {
+		return fmt.Errorf("logged in to site %s instead of %s", session.SiteID, t.SiteID)
+	}
+
+	t.Token = session.Token
+	t.UserID = session.UserID
+
+	return nil
+}
+
+// sendRequest sends request to the server and decodes the response.
+// If payload is not nil, it's encoded as XML request body. If result is not nil, response body is decoded into it.
+// If token is not empty, it's sent in the X-Tableau-Auth header.
+// Failed attempts are retried according to the retry policy - see SetRetryPolicy.
+// Returns non-nil error if
+// - fails to encode payload, construct or send the request
+// - server responds with other than expected status code - the error is TableauError
+// - fails to decode the response
+func sendRequest(method, url, token string, payload, result interface{}, expectedStatus int) error {
+	var data []byte
+	if payload != nil {
+		var err error
+		data, err = xml.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	policy := currentRetryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest(method, url, token, data)
+		if err != nil {
+			return err
+		}
+
+		status, header, err := roundTrip(req, result, expectedStatus)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(method, status, err) {
+			return err
+		}
+
+		delay := policy.delay(attempt, header.Get("Retry-After"))
+		log.Warnf("%s %s failed (attempt %d/%d), retrying in %s: %s", method, url, attempt, policy.MaxAttempts,
+			delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// newRequest constructs request with XML body data and authentication token, if set.
+func newRequest(method, url, token string, data []byte) (*http.Request, error) {
+	var requestBody io.Reader
+	if data != nil {
+		requestBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	if token != "" {
+		req.Header.Set("X-Tableau-Auth", token)
+	}
+
+	return req, nil
+}
+
+// roundTrip sends the request once and decodes the response - see sendRequest.
+// Returns status code and headers of the response; status code is 0 if no response was received.
+func roundTrip(req *http.Request, result interface{}, expectedStatus int) (int, http.Header, error) {
+	log.Debugf("%s %s", req.Method, req.URL)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send %s request: %w", req.Method, err)
+	}
+
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			panic(err)
+		}
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	log.Debugf("response body: %s", string(body))
+	log.Debugf("response code: %d", resp.StatusCode)
+
+	if resp.StatusCode != expectedStatus {
+		return resp.StatusCode, resp.Header, responseError(resp.StatusCode, body)
+	}
+
+	if result != nil {
+		if err := xml.Unmarshal(body, result); err != nil {
+			return resp.StatusCode, resp.Header, fmt.Errorf("unable to unmarshal response body: %w", err)
+		}
+	}
+
+	return resp.StatusCode, resp.Header, nil
+}
+
+// responseError maps unexpected server response to TableauError.
+func responseError(statusCode int, body []byte) error {
+	tableauErr := &TableauError{StatusCode: statusCode}
+
+	var errorResponse ErrorResponse
+	if err := xml.Unmarshal(body, &errorResponse); err != nil || errorResponse.Error.Code == "" {
+		tableauErr.Detail = string(body)
+	} else {
+		tableauErr.Code = errorResponse.Error.Code
+		tableauErr.Summary = errorResponse.Error.Summary
+		tableauErr.Detail = errorResponse.Error.Detail
+	}
+
+	return tableauErr
+}
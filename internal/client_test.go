@@ -0,0 +1,375 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly, so that tests with injected failures don't wait.
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+// newTestServer starts stand-in server and sets testRetryPolicy; both are reset when the test finishes.
+func newTestServer(t *testing.T) *tableautest.Server {
+	t.Helper()
+
+	server := tableautest.NewServer()
+	t.Cleanup(server.Close)
+
+	SetRetryPolicy(testRetryPolicy)
+	t.Cleanup(func() { SetRetryPolicy(DefaultRetryPolicy) })
+
+	return server
+}
+
+// newTestTableau returns client signed in to the Default site of the server as its admin user.
+func newTestTableau(t *testing.T, server *tableautest.Server) *Tableau {
+	t.Helper()
+
+	credentials := Credentials{Username: server.Username, Password: server.Password}
+	tableau, err := NewTableau(server.BaseURL(), credentials, "", filepath.Join(t.TempDir(), "session.json"))
+	if err != nil {
+		t.Fatalf("failed to sign in: %s", err)
+	}
+	return tableau
+}
+
+// usersEndpoint returns key of server.Requests counting requests of given method to users of the site.
+func usersEndpoint(method string, tableau *Tableau) string {
+	return fmt.Sprintf("%s /sites/%s/users", method, tableau.SiteID)
+}
+
+func TestLogin(t *testing.T) {
+	server := newTestServer(t)
+	server.Tokens["ci-token"] = "token-secret"
+	server.ConnectedApps["client-id"] = tableautest.ConnectedApp{SecretID: "secret-id", SecretValue: "secret-value"}
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		identity    string
+		wantErr     string
+	}{
+		{
+			name:        "password",
+			credentials: Credentials{Username: "admin", Password: "password"},
+			identity:    "user:admin",
+		},
+		{
+			name:        "wrong password",
+			credentials: Credentials{Username: "admin", Password: "wrong"},
+			wantErr:     "(admin:*****)",
+		},
+		{
+			name:        "personal access token",
+			credentials: Credentials{TokenName: "ci-token", TokenSecret: "token-secret"},
+			identity:    "token:ci-token",
+		},
+		{
+			name:        "unknown personal access token",
+			credentials: Credentials{TokenName: "other-token", TokenSecret: "token-secret"},
+			wantErr:     "(token other-token:t*****t)",
+		},
+		{
+			name: "connected app JWT",
+			credentials: Credentials{Username: "admin", ClientID: "client-id", SecretID: "secret-id",
+				SecretValue: "secret-value", Scopes: []string{"tableau:users:read"}},
+			identity: "jwt:client-id:admin",
+		},
+		{
+			name: "JWT of unknown connected app",
+			credentials: Credentials{Username: "admin", ClientID: "other-client", SecretID: "secret-id",
+				SecretValue: "secret-value", Scopes: []string{"tableau:users:read"}},
+			wantErr: "(connected app other-client as admin)",
+		},
+		{
+			name: "JWT signed with wrong secret",
+			credentials: Credentials{Username: "admin", ClientID: "client-id", SecretID: "secret-id",
+				SecretValue: "other-value", Scopes: []string{"tableau:users:read"}},
+			wantErr: "(connected app client-id as admin)",
+		},
+		{
+			name: "JWT with unknown secret ID",
+			credentials: Credentials{Username: "admin", ClientID: "client-id", SecretID: "other-id",
+				SecretValue: "secret-value", Scopes: []string{"tableau:users:read"}},
+			wantErr: "(connected app client-id as admin)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := Login(server.BaseURL(), tt.credentials, "")
+			if tt.wantErr != "" {
+				if !HasErrorCode(err, "401001") {
+					t.Fatalf("expected sign in error 401001, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error '%s' doesn't describe credentials as %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if session.Token == "" || session.SiteID == "" || session.UserID == "" {
+				t.Errorf("session is missing token, site or user: %+v", session)
+			}
+			if session.Identity != tt.identity {
+				t.Errorf("got identity %s, expected %s", session.Identity, tt.identity)
+			}
+			if session.Expired(time.Now()) {
+				t.Errorf("new session expires already at %s", session.ExpiresAt)
+			}
+			if err := session.Tableau().CheckSession(); err != nil {
+				t.Errorf("server doesn't accept the session: %s", err)
+			}
+		})
+	}
+}
+
+func TestReloginOnExpiredSession(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	expiredToken := tableau.Token
+
+	server.ExpireSessions()
+
+	user, err := tableau.GetUser("admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !user.Exists {
+		t.Errorf("signed-in user was not found")
+	}
+	if tableau.Token == expiredToken {
+		t.Errorf("expired token was not replaced")
+	}
+	if n := server.Requests["POST /auth/signin"]; n != 2 {
+		t.Errorf("signed in %d times, expected once more after the session expired", n)
+	}
+	if n := server.Requests[usersEndpoint(http.MethodGet, tableau)]; n != 2 {
+		t.Errorf("request was sent %d times, expected to be replayed once", n)
+	}
+	if n := server.Sessions(); n != 1 {
+		t.Errorf("got %d active sessions, expected 1", n)
+	}
+}
+
+func TestExpiredSessionWithoutRelogin(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	tableau.Relogin = nil
+
+	server.ExpireSessions()
+
+	_, err := tableau.GetUser("admin")
+	if !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expected expired session error, got %v", err)
+	}
+	if n := server.Requests["POST /auth/signin"]; n != 1 {
+		t.Errorf("signed in %d times, expected only once", n)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("GET is retried", func(t *testing.T) {
+		server := newTestServer(t)
+		tableau := newTestTableau(t, server)
+
+		server.Fail(2, http.StatusServiceUnavailable, "")
+
+		users, err := tableau.GetUsers(ListQuery{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(users) != 1 {
+			t.Errorf("got %d users, expected only the signed-in user", len(users))
+		}
+		if n := server.Requests[usersEndpoint(http.MethodGet, tableau)]; n != 3 {
+			t.Errorf("request was sent %d times, expected 3", n)
+		}
+	})
+
+	t.Run("GET gives up after max attempts", func(t *testing.T) {
+		server := newTestServer(t)
+		tableau := newTestTableau(t, server)
+
+		server.Fail(testRetryPolicy.MaxAttempts, http.StatusServiceUnavailable, "")
+
+		_, err := tableau.GetUsers(ListQuery{})
+		if !HasStatusCode(err, http.StatusServiceUnavailable) {
+			t.Fatalf("expected 503 error, got %v", err)
+		}
+		if n := server.Requests[usersEndpoint(http.MethodGet, tableau)]; n != testRetryPolicy.MaxAttempts {
+			t.Errorf("request was sent %d times, expected %d", n, testRetryPolicy.MaxAttempts)
+		}
+	})
+
+	t.Run("POST is not retried", func(t *testing.T) {
+		server := newTestServer(t)
+		tableau := newTestTableau(t, server)
+
+		server.Fail(2, http.StatusServiceUnavailable, "")
+
+		_, err := tableau.CreateUser(User{Username: "jane.doe", Role: "Viewer"})
+		if !HasStatusCode(err, http.StatusServiceUnavailable) {
+			t.Fatalf("expected 503 error, got %v", err)
+		}
+		if n := server.Requests[usersEndpoint(http.MethodPost, tableau)]; n != 1 {
+			t.Errorf("request was sent %d times, expected once", n)
+		}
+	})
+
+	t.Run("POST is retried on 429", func(t *testing.T) {
+		server := newTestServer(t)
+		tableau := newTestTableau(t, server)
+
+		server.Fail(1, http.StatusTooManyRequests, "")
+
+		if _, err := tableau.CreateUser(User{Username: "jane.doe", Role: "Viewer"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if n := server.Requests[usersEndpoint(http.MethodPost, tableau)]; n != 2 {
+			t.Errorf("request was sent %d times, expected 2", n)
+		}
+	})
+}
+
+func TestTableauError(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	_, err := tableau.GetUsers(ListQuery{Filters: []string{"bogus"}})
+
+	var tableauErr *TableauError
+	if !errors.As(err, &tableauErr) {
+		t.Fatalf("expected TableauError, got %v", err)
+	}
+	if tableauErr.StatusCode != http.StatusBadRequest || tableauErr.Code != "400065" ||
+		tableauErr.Summary != "Bad Request" || !strings.Contains(tableauErr.Detail, "bogus") {
+		t.Errorf("unexpected error: %+v", tableauErr)
+	}
+	if !HasErrorCode(err, "400065") || !HasStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("error code or status code doesn't match: %v", err)
+	}
+	if HasErrorCode(err, UserExistsErrorCode) || errors.Is(err, ErrSessionExpired) {
+		t.Errorf("error matches other error: %v", err)
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected TableauError
+	}{
+		{
+			name:   "Tableau error",
+			status: http.StatusConflict,
+			body: `<?xml version="1.0" encoding="UTF-8"?><tsResponse xmlns="http://tableau.com/api">` +
+				`<error code="409017"><summary>Conflict</summary><detail>user exists</detail></error></tsResponse>`,
+			expected: TableauError{StatusCode: http.StatusConflict, Code: "409017", Summary: "Conflict",
+				Detail: "user exists"},
+		},
+		{
+			name:     "response of proxy",
+			status:   http.StatusBadGateway,
+			body:     "<html><body>Bad Gateway</body></html>",
+			expected: TableauError{StatusCode: http.StatusBadGateway, Detail: "<html><body>Bad Gateway</body></html>"},
+		},
+		{
+			name:     "empty response",
+			status:   http.StatusServiceUnavailable,
+			expected: TableauError{StatusCode: http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := responseError(tt.status, []byte(tt.body))
+
+			tableauErr, ok := AsTableauError(fmt.Errorf("wrapped: %w", err))
+			if !ok {
+				t.Fatalf("expected TableauError, got %v", err)
+			}
+			if *tableauErr != tt.expected {
+				t.Errorf("got %+v, expected %+v", tableauErr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUsersPagination(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	for i := 1; i <= 24; i++ {
+		site.AddUser(tableautest.User{Name: fmt.Sprintf("user%02d", i)})
+	}
+
+	users, err := tableau.GetUsers(ListQuery{Sort: []string{"name:desc"}, PageSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(users) != 25 {
+		t.Fatalf("got %d users, expected 25", len(users))
+	}
+	for i, user := range users[:24] {
+		if expected := fmt.Sprintf("user%02d", 24-i); user.Username != expected {
+			t.Errorf("user %d is %s, expected %s", i, user.Username, expected)
+		}
+	}
+	if users[24].Username != "admin" {
+		t.Errorf("last user is %s, expected admin", users[24].Username)
+	}
+	if n := server.Requests[usersEndpoint(http.MethodGet, tableau)]; n != 3 {
+		t.Errorf("fetched %d pages, expected 3", n)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	tableau.DryRun = true
+
+	site := server.Sites[""]
+	creator := site.AddUser(tableautest.User{Name: "john.smith", SiteRole: "Creator"})
+	leaving := site.AddUser(tableautest.User{Name: "bob.x", SiteRole: "Viewer"})
+
+	user, err := tableau.CreateUser(User{Username: "new.guy", Role: "Explorer", Email: "new.guy@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != DryRunID {
+		t.Errorf("got user ID %s, expected %s", user.ID, DryRunID)
+	}
+	if _, err := tableau.DeleteUser(leaving.ID, creator.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tableau.GetAssetsUser("john.smith"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	for request, n := range server.Requests {
+		if !strings.HasPrefix(request, http.MethodGet+" ") && request != "POST /auth/signin" {
+			t.Errorf("dry run sent %d %s requests", n, request)
+		}
+	}
+	if server.Requests[usersEndpoint(http.MethodGet, tableau)] == 0 {
+		t.Errorf("dry run didn't look up users")
+	}
+	if site.User("new.guy") != nil || site.User("bob.x") == nil {
+		t.Errorf("dry run changed users on the server")
+	}
+}
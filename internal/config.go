@@ -1,11 +1,29 @@
 package internal
 
-import "github.com/spf13/viper"
+import (
+	"github.com/spf13/viper"
+	"strings"
+)
 
 type Config struct {
-	TableauURL string `mapstructure:"TABLEAU_URL"`
-	Username   string `mapstructure:"TABLEAU_USERNAME"`
-	Password   string `mapstructure:"TABLEAU_PASSWORD"`
+	TableauURL  string `mapstructure:"TABLEAU_URL"`
+	Username    string `mapstructure:"TABLEAU_USERNAME"`
+	Password    string `mapstructure:"TABLEAU_PASSWORD"`
+	TokenName   string `mapstructure:"TABLEAU_TOKEN_NAME"`
+	TokenSecret string `mapstructure:"TABLEAU_TOKEN_SECRET"`
+	ClientID    string `mapstructure:"TABLEAU_JWT_CLIENT_ID"`
+	SecretID    string `mapstructure:"TABLEAU_JWT_SECRET_ID"`
+	SecretValue string `mapstructure:"TABLEAU_JWT_SECRET_VALUE"`
+	Scopes      string `mapstructure:"TABLEAU_JWT_SCOPES"`
+	Site        string `mapstructure:"TABLEAU_SITE"`
+
+	RetryMaxAttempts   int     `mapstructure:"TABLEAU_RETRY_MAX_ATTEMPTS"`
+	RetryBackoff       string  `mapstructure:"TABLEAU_RETRY_BACKOFF"`
+	RetryMaxBackoff    string  `mapstructure:"TABLEAU_RETRY_MAX_BACKOFF"`
+	RetryMaxRetryAfter string  `mapstructure:"TABLEAU_RETRY_MAX_RETRY_AFTER"`
+	RetryJitter        float64 `mapstructure:"TABLEAU_RETRY_JITTER"`
+
+	Concurrency int `mapstructure:"TABLEAU_CONCURRENCY"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -24,3 +42,49 @@ func LoadConfig(path string) (config Config, err error) {
 
 	return
 }
+
+// LoadCredentials returns credentials for signing in from the loaded configuration and flags.
+// Scopes of Connected App JWT are separated by spaces or commas.
+func LoadCredentials() Credentials {
+	scopes := viper.GetString(strings.ToLower(JWTScopesVar))
+	if scopes == "" {
+		scopes = DefaultJWTScopes
+	}
+
+	return Credentials{
+		Username:    viper.GetString("tableau_username"),
+		Password:    viper.GetString("tableau_password"),
+		TokenName:   viper.GetString(strings.ToLower(TokenNameVar)),
+		TokenSecret: viper.GetString(strings.ToLower(TokenSecretVar)),
+		ClientID:    viper.GetString(strings.ToLower(JWTClientIDVar)),
+		SecretID:    viper.GetString(strings.ToLower(JWTSecretIDVar)),
+		SecretValue: viper.GetString(strings.ToLower(JWTSecretValueVar)),
+		Scopes: strings.FieldsFunc(scopes, func(r rune) bool {
+			return r == ' ' || r == ','
+		}),
+	}
+}
+
+// LoadRetryPolicy returns retry policy from the loaded configuration; unset values are taken from DefaultRetryPolicy.
+// Backoffs are durations like "500ms" or "2s".
+func LoadRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy
+
+	if viper.IsSet(strings.ToLower(RetryMaxAttemptsVar)) {
+		policy.MaxAttempts = viper.GetInt(strings.ToLower(RetryMaxAttemptsVar))
+	}
+	if viper.IsSet(strings.ToLower(RetryBackoffVar)) {
+		policy.Backoff = viper.GetDuration(strings.ToLower(RetryBackoffVar))
+	}
+	if viper.IsSet(strings.ToLower(RetryMaxBackoffVar)) {
+		policy.MaxBackoff = viper.GetDuration(strings.ToLower(RetryMaxBackoffVar))
+	}
+	if viper.IsSet(strings.ToLower(RetryMaxRetryAfterVar)) {
+		policy.MaxRetryAfter = viper.GetDuration(strings.ToLower(RetryMaxRetryAfterVar))
+	}
+	if viper.IsSet(strings.ToLower(RetryJitterVar)) {
+		policy.Jitter = viper.GetFloat64(strings.ToLower(RetryJitterVar))
+	}
+
+	return policy
+}
@@ -1,13 +1,76 @@
 package internal
 
-import log "github.com/sirupsen/logrus"
+import (
+	log "github.com/sirupsen/logrus"
+	"time"
+)
 
 const DefaultRole = "Viewer"
 const DefaultAuthSetting = SamlAuthSetting
 const DefaultLogLevel = log.WarnLevel
+const DefaultHTTPTimeout = 60 * time.Second
+const DefaultJWTScopes = "tableau:users:read tableau:users:create tableau:users:update tableau:users:delete " +
+	"tableau:groups:read tableau:groups:create tableau:groups:update tableau:groups:delete " +
+	"tableau:projects:read tableau:projects:create tableau:projects:update tableau:projects:delete " +
+	"tableau:content:read tableau:jobs:read"
+
+// DefaultSessionLifetime is used when server doesn't tell when the session expires; it's Tableau's default.
+const DefaultSessionLifetime = 240 * time.Minute
+
+// SessionExpirationMargin - cached session is not reused when it expires sooner than that.
+const SessionExpirationMargin = time.Minute
+
+// JWTLifetime of Connected App tokens; Tableau accepts at most 10 minutes.
+const JWTLifetime = 5 * time.Minute
+
+// DefaultPageSize of lists fetched from the server; it's the maximum Tableau allows.
+const DefaultPageSize = 1000
+
+// DefaultConcurrency - number of pages of a list fetched in parallel.
+const DefaultConcurrency = 4
+
+// DryRunID is ID of users and groups created in dry run, which is not known until they are created.
+const DryRunID = "<new-id>"
 
 const SamlAuthSetting = "SAML"
 
+// LocalDomain of groups created on the site, as opposed to groups imported from Active Directory.
+const LocalDomain = "local"
+
+// ProjectPathSeparator separates names of projects in paths of nested projects, e.g. Finance/Reports.
+const ProjectPathSeparator = "/"
+
+// ActiveDirectorySource of groups imported from Active Directory.
+const ActiveDirectorySource = "ActiveDirectory"
+
+// DefaultImportSiteRole is minimum site role of groups imported from Active Directory, unless set otherwise;
+// Tableau requires one.
+const DefaultImportSiteRole = "Unlicensed"
+
+// GroupSyncJobType is type of jobs synchronizing groups with Active Directory.
+const GroupSyncJobType = "GroupSync"
+
+// JobPollInterval - how often the server is asked about progress of a job being waited for.
+const JobPollInterval = 2 * time.Second
+
+// DefaultJobWaitTimeout - how long to wait for a job to complete, unless set otherwise.
+const DefaultJobWaitTimeout = 30 * time.Minute
+
+const SiteVar = "TABLEAU_SITE"
+const TokenNameVar = "TABLEAU_TOKEN_NAME"
+const TokenSecretVar = "TABLEAU_TOKEN_SECRET"
+const JWTClientIDVar = "TABLEAU_JWT_CLIENT_ID"
+const JWTSecretIDVar = "TABLEAU_JWT_SECRET_ID"
+const JWTSecretValueVar = "TABLEAU_JWT_SECRET_VALUE"
+const JWTScopesVar = "TABLEAU_JWT_SCOPES"
+const SessionFileVar = "TABLEAU_SESSION_FILE"
+const RetryMaxAttemptsVar = "TABLEAU_RETRY_MAX_ATTEMPTS"
+const RetryBackoffVar = "TABLEAU_RETRY_BACKOFF"
+const RetryMaxBackoffVar = "TABLEAU_RETRY_MAX_BACKOFF"
+const RetryMaxRetryAfterVar = "TABLEAU_RETRY_MAX_RETRY_AFTER"
+const RetryJitterVar = "TABLEAU_RETRY_JITTER"
+const ConcurrencyVar = "TABLEAU_CONCURRENCY"
+
 const ExistingAssetsUserNameVar = "TABLEAU_EXISTING_ASSETS_USER_NAME"
 
 const EnvVarLogLevel = "LOG_LEVEL"
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// OwnedContent counts content owned by a user.
+type OwnedContent struct {
+	Workbooks   int `json:"workbooks" yaml:"workbooks"`
+	Datasources int `json:"datasources" yaml:"datasources"`
+	Flows       int `json:"flows" yaml:"flows"`
+}
+
+// Total returns number of all owned items.
+func (c OwnedContent) Total() int {
+	return c.Workbooks + c.Datasources + c.Flows
+}
+
+type paginatedResponse struct {
+	XMLName    xml.Name   `xml:"tsResponse"`
+	Pagination Pagination `xml:"pagination"`
+}
+
+// GetOwnedContent counts workbooks, data sources and flows owned by the user.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_workbooks_and_views.htm#query_workbooks_for_user
+// API Endpoints: GET /api/api-version/sites/site-id/users/user-id/workbooks?ownedBy=true,
+// GET /api/api-version/sites/site-id/datasources?filter=ownerName:eq:username,
+// GET /api/api-version/sites/site-id/flows?filter=ownerName:eq:username
+func (t *Tableau) GetOwnedContent(user *User) (*OwnedContent, error) {
+	ownerFilter := url.QueryEscape(Filter("ownerName", "eq", user.Username))
+
+	var content OwnedContent
+	counts := []struct {
+		count *int
+		url   string
+	}{
+		{&content.Workbooks, t.siteURL("users/%s/workbooks?ownedBy=true&pageSize=1", user.ID)},
+		{&content.Datasources, t.siteURL("datasources?filter=%s&pageSize=1", ownerFilter)},
+		{&content.Flows, t.siteURL("flows?filter=%s&pageSize=1", ownerFilter)},
+	}
+
+	for _, c := range counts {
+		var response paginatedResponse
+		if err := t.do(http.MethodGet, c.url, nil, &response, http.StatusOK); err != nil {
+			return nil, fmt.Errorf("failed to count content owned by %s: %w", user.Username, err)
+		}
+		*c.count = response.Pagination.TotalAvailable
+	}
+
+	return &content, nil
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type CreateGroupRequest struct {
+	XMLName xml.Name                `xml:"tsRequest"`
+	Group   CreateGroupRequestGroup `xml:"group"`
+}
+
+type CreateGroupRequestGroup struct {
+	Name            string       `xml:"name,attr"`
+	MinimumSiteRole string       `xml:"minimumSiteRole,attr,omitempty"`
+	Import          *GroupImport `xml:"import,omitempty"`
+}
+
+// GroupImport tells where group is imported or synchronized from, and license of its members.
+type GroupImport struct {
+	Source           string `xml:"source,attr"`
+	DomainName       string `xml:"domainName,attr"`
+	SiteRole         string `xml:"siteRole,attr"`
+	GrantLicenseMode string `xml:"grantLicenseMode,attr,omitempty"`
+}
+
+type CreateGroupResponse struct {
+	XMLName xml.Name              `xml:"tsResponse"`
+	Group   GetGroupResponseGroup `xml:"group"`
+}
+
+// CreateGroup creates local group with given name and, if set, minimum site role which users get when they're added
+// to the group. If domain of the group is set and isn't local, the group is imported from Active Directory with its
+// members instead - see importOf.
+// Returns the group with Exists set to true and error if the group already exists.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#create_group
+// API Endpoint: POST /api/api-version/sites/site-id/groups
+func (t *Tableau) CreateGroup(g Group) (*Group, error) {
+	createGroupURL := t.siteURL("groups")
+
+	log.Debugf("Creating group on %s", createGroupURL)
+
+	payload := CreateGroupRequest{
+		Group: CreateGroupRequestGroup{
+			Name:            g.Name,
+			MinimumSiteRole: g.MinimumSiteRole,
+			Import:          importOf(g),
+		},
+	}
+	if payload.Group.Import != nil {
+		payload.Group.MinimumSiteRole = ""
+	}
+
+	var createGroupResponse CreateGroupResponse
+	err := t.do(http.MethodPost, createGroupURL, payload, &createGroupResponse, http.StatusCreated)
+	if HasErrorCode(err, GroupExistsErrorCode) {
+		g.Exists = true
+		return &g, fmt.Errorf("group %s already exists: %w", g.Name, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	if t.DryRun {
+		if g.Domain == "" {
+			g.Domain = LocalDomain
+		}
+		g.Exists, g.ID = true, DryRunID
+		return &g, nil
+	}
+
+	return createGroupResponse.Group.toGroup(), nil
+}
+
+// importOf returns import element of requests importing or synchronizing group from Active Directory, or nil for
+// local group. Minimum site role defaults to DefaultImportSiteRole.
+func importOf(g Group) *GroupImport {
+	if g.Domain == "" || g.Domain == LocalDomain {
+		return nil
+	}
+	role := g.MinimumSiteRole
+	if role == "" {
+		role = DefaultImportSiteRole
+	}
+	return &GroupImport{
+		Source:           ActiveDirectorySource,
+		DomainName:       g.Domain,
+		SiteRole:         role,
+		GrantLicenseMode: g.GrantLicenseMode,
+	}
+}
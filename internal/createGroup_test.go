@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"testing"
+)
+
+func TestCreateGroup(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	server.Sites[""].AddGroup(tableautest.Group{Name: "Finance"})
+
+	group, err := tableau.CreateGroup(Group{Name: "Sales", MinimumSiteRole: "Explorer"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !group.Exists || group.ID == "" || group.Domain != LocalDomain || group.MinimumSiteRole != "Explorer" {
+		t.Errorf("got group %+v, expected new local Explorer group", group)
+	}
+
+	group, err = tableau.CreateGroup(Group{Name: "finance"})
+	if !HasErrorCode(err, GroupExistsErrorCode) {
+		t.Errorf("got error %v, expected %s", err, GroupExistsErrorCode)
+	}
+	if group == nil || !group.Exists {
+		t.Errorf("got group %+v, expected existing group", group)
+	}
+
+	// Conflict other than existing group is a plain failure.
+	server.Fail(1, http.StatusConflict, "409000")
+	group, err = tableau.CreateGroup(Group{Name: "Marketing"})
+	if err == nil || group != nil {
+		t.Errorf("got group %+v and error %v, expected only error", group, err)
+	}
+}
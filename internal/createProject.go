@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// ContentPermissions modes of projects - whether permissions of content are locked to the project (optionally
+// without nested projects), or managed by content owners.
+var ContentPermissions = []string{
+	"LockedToProject",
+	"LockedToProjectWithoutNested",
+	"ManagedByOwner",
+}
+
+type CreateProjectRequest struct {
+	XMLName xml.Name              `xml:"tsRequest"`
+	Project ProjectRequestProject `xml:"project"`
+}
+
+// ProjectRequestProject is project in create and update requests. ParentProjectID is nil to keep the parent, and
+// points to empty string to move the project to the top level.
+type ProjectRequestProject struct {
+	ParentProjectID    *string `xml:"parentProjectId,attr,omitempty"`
+	Name               string  `xml:"name,attr,omitempty"`
+	Description        string  `xml:"description,attr,omitempty"`
+	ContentPermissions string  `xml:"contentPermissions,attr,omitempty"`
+}
+
+type ProjectResponse struct {
+	XMLName xml.Name                  `xml:"tsResponse"`
+	Project GetProjectResponseProject `xml:"project"`
+}
+
+// ValidateContentPermissions returns content permissions mode as spelled by Tableau (matching is case-insensitive),
+// or error if it's not valid.
+func ValidateContentPermissions(mode string) (string, error) {
+	return validateValue("content permissions", mode, ContentPermissions)
+}
+
+// CreateProject creates project with given name, description and content permissions in parent project given by
+// ParentID, or top-level project if it's empty. Path of the project is kept as it is.
+// Returns the project with Exists set to true and error if the project already exists.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#create_project
+// API Endpoint: POST /api/api-version/sites/site-id/projects
+func (t *Tableau) CreateProject(p Project) (*Project, error) {
+	createProjectURL := t.siteURL("projects")
+
+	log.Debugf("Creating project on %s", createProjectURL)
+
+	payload := CreateProjectRequest{
+		Project: ProjectRequestProject{
+			Name:               p.Name,
+			Description:        p.Description,
+			ContentPermissions: p.ContentPermissions,
+		},
+	}
+	if p.ParentID != "" {
+		payload.Project.ParentProjectID = &p.ParentID
+	}
+
+	var createProjectResponse ProjectResponse
+	err := t.do(http.MethodPost, createProjectURL, payload, &createProjectResponse, http.StatusCreated)
+	if HasErrorCode(err, ProjectExistsErrorCode) {
+		p.Exists = true
+		return &p, fmt.Errorf("project %s already exists: %w", p.Name, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	if t.DryRun {
+		p.Exists, p.ID = true, DryRunID
+		return &p, nil
+	}
+
+	project := createProjectResponse.Project.toProject()
+	project.Path, project.Level = p.Path, p.Level
+	return project, nil
+}
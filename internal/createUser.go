@@ -1,87 +1,143 @@
 package internal
 
 import (
-	"bytes"
 	"encoding/xml"
 	"fmt"
-	"github.com/fastbill/go-httperrors"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
-	"io"
 	"net/http"
 )
 
+type CreateUserRequest struct {
+	XMLName xml.Name              `xml:"tsRequest"`
+	User    CreateUserRequestUser `xml:"user"`
+}
+
+type CreateUserRequestUser struct {
+	Name        string `xml:"name,attr"`
+	SiteRole    string `xml:"siteRole,attr"`
+	AuthSetting string `xml:"authSetting,attr,omitempty"`
+}
+
+// CreateUsersResult is outcome of creating one user of many - see CreateUsers.
+type CreateUsersResult struct {
+	Username    string `json:"username" yaml:"username"`
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Role        string `json:"role" yaml:"role"`
+	AuthSetting string `json:"authSetting" yaml:"authSetting"`
+	Result      string `json:"result" yaml:"result"`
+	Error       string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Results of creating a user.
+const (
+	CreateResultCreated  = "created"
+	CreateResultExisting = "existing"
+	CreateResultError    = "error"
+)
+
+// CreateUsersSummary counts users created by CreateUsers, users which already existed and users which failed to be
+// created, and lists the outcome for every user.
+type CreateUsersSummary struct {
+	Created  int                 `json:"created" yaml:"created"`
+	Existing int                 `json:"existing" yaml:"existing"`
+	Errored  int                 `json:"errored" yaml:"errored"`
+	Users    []CreateUsersResult `json:"users" yaml:"users"`
+}
+
+func (s *CreateUsersSummary) Items() interface{} {
+	return s.Users
+}
+
 type CreateUserResponse struct {
 	XMLName xml.Name            `xml:"tsResponse"`
 	User    GetUserResponseUser `xml:"user"`
 }
 
-func (t Tableau) CreateUser(u User) (*User, error) {
-	createUserURL := fmt.Sprintf("%s/sites/%s/users/", t.BaseURL, t.SiteID)
+// CreateUser adds user to the site with given username, site role and auth setting (DefaultAuthSetting if empty).
+// Full name and email, if set, are set by updating the user after it's added, since they can't be set when adding.
+// Returns the user with Exists set to true and error if the user already exists.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#add_user_to_site
+// API Endpoint: POST /api/api-version/sites/site-id/users
+func (t *Tableau) CreateUser(u User) (*User, error) {
+	createUserURL := t.siteURL("users/")
 
-	log.Debugf("Searching for user on %s", createUserURL)
+	log.Debugf("Creating user on %s", createUserURL)
 
-	requestBody := []byte(fmt.Sprintf(`
-<tsRequest>
-  <user name="%s" siteRole="%s" authSetting="%s" />
-</tsRequest>
-`, u.Username, u.Role, DefaultAuthSetting))
-
-	req, err := http.NewRequest(http.MethodPost, createUserURL, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create create_user request: %w", err)
+	authSetting := u.AuthSetting
+	if authSetting == "" {
+		authSetting = DefaultAuthSetting
 	}
 
-	req.Header.Set("X-Tableau-Auth", t.Token)
+	payload := CreateUserRequest{
+		User: CreateUserRequestUser{
+			Name:        u.Username,
+			SiteRole:    u.Role,
+			AuthSetting: authSetting,
+		},
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var createUserResponse CreateUserResponse
+	err := t.do(http.MethodPost, createUserURL, payload, &createUserResponse, http.StatusCreated)
+	if HasErrorCode(err, UserExistsErrorCode) {
+		u.Exists = true
+		return &u, fmt.Errorf("user %s already exists: %w", u.Username, err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to send create_user request: %w", err)
+		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			panic(err)
-		}
-	}(resp.Body)
+	log.Debugf("unmarshaled response: %v", createUserResponse.User)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read 	response body: %w", err)
+	newUser := createUserResponse.User.toUser()
+	if t.DryRun {
+		newUser = &User{Exists: true, ID: DryRunID, Username: u.Username, Role: u.Role, AuthSetting: authSetting}
 	}
-
-	log.Debugf("response body: %s", string(body))
-	log.Debugf("response code: %d", resp.StatusCode)
-
-	if resp.StatusCode != http.StatusCreated {
-		if resp.StatusCode == http.StatusUnauthorized {
-			password := viper.GetString("tableau_password")
-			return nil, fmt.Errorf("invalid credentials [%d] (%s:%s)", resp.StatusCode,
-				viper.GetString("tableau_username"),
-				fmt.Sprintf("%s*****%s", password[0:1], password[len(password)-1:]))
-		} else if resp.StatusCode == http.StatusConflict {
-			u.Exists = true
-			return &u, fmt.Errorf("user %s already exists", u.Username)
-		}
-		return nil, httperrors.New(resp.StatusCode, fmt.Sprintf("failed to create user - server responded with status code: %d - %s", resp.StatusCode, string(body)))
+	if u.FullName == "" && u.Email == "" {
+		return newUser, nil
 	}
 
-	var createUserResponse CreateUserResponse
-	if err := xml.Unmarshal(body, &createUserResponse); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal response body: %w", err)
+	updatedUser, err := t.UpdateUser(User{ID: newUser.ID, FullName: u.FullName, Email: u.Email})
+	if err != nil {
+		return newUser, fmt.Errorf("user %s created, but failed to set full name and email: %w", u.Username, err)
+	}
+	if t.DryRun {
+		updatedUser.Username, updatedUser.Role, updatedUser.AuthSetting = u.Username, u.Role, authSetting
 	}
 
-	log.Debugf("unmarshaled response: %v", createUserResponse.User)
+	return updatedUser, nil
+}
+
+// CreateUsers creates users one by one - see CreateUser - and returns summary of the outcomes. Users which already
+// exist are skipped, and failure to create a user doesn't stop creating the rest.
+func (t *Tableau) CreateUsers(users []*User) *CreateUsersSummary {
+	summary := &CreateUsersSummary{Users: make([]CreateUsersResult, 0, len(users))}
+
+	for idx, user := range users {
+		result := CreateUsersResult{Username: user.Username, Role: user.Role, AuthSetting: user.AuthSetting}
+
+		created, err := t.CreateUser(*user)
+		switch {
+		case created != nil && created.Exists && err == nil:
+			summary.Created++
+			result.Result = CreateResultCreated
+			result.ID = created.ID
+			log.Infof("[%d/%d] User %s created as %s", idx+1, len(users), user.Username, user.Role)
+		case HasErrorCode(err, UserExistsErrorCode):
+			summary.Existing++
+			result.Result = CreateResultExisting
+			log.Infof("[%d/%d] User %s already exists - skipping", idx+1, len(users), user.Username)
+		default:
+			summary.Errored++
+			result.Result = CreateResultError
+			result.Error = err.Error()
+			if created != nil {
+				result.ID = created.ID
+			}
+			log.Errorf("[%d/%d] Failed to create user %s: %s", idx+1, len(users), user.Username, err)
+		}
 
-	newUser := User{
-		Username:    createUserResponse.User.Name,
-		ID:          createUserResponse.User.ID,
-		Role:        createUserResponse.User.SiteRole,
-		AuthSetting: createUserResponse.User.AuthSetting,
-		Exists:      true,
+		summary.Users = append(summary.Users, result)
 	}
 
-	return &newUser, nil
+	return summary
 }
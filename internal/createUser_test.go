@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateUsersFromFile(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	server.Sites[""].AddUser(tableautest.User{Name: "jane.doe", SiteRole: "Viewer"})
+
+	path := filepath.Join(t.TempDir(), "users.csv")
+	content := "username,role,authSetting\n" +
+		"carl,Explorer,SAML\n" +
+		"john.smith,Creator,SAML\n" +
+		"Jane.Doe,Viewer,SAML\n" +
+		"bob.x,,SAML\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	users, err := ReadUsersFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Conflict other than existing user fails creating of the first user; the server requires site role of bob.x.
+	server.Fail(1, http.StatusConflict, "409000")
+
+	summary := tableau.CreateUsers(users)
+
+	expected := []struct {
+		username string
+		result   string
+	}{
+		{"carl", CreateResultError},
+		{"john.smith", CreateResultCreated},
+		{"Jane.Doe", CreateResultExisting},
+		{"bob.x", CreateResultError},
+	}
+
+	if summary.Created != 1 || summary.Existing != 1 || summary.Errored != 2 {
+		t.Errorf("got %d created, %d existing and %d failed users, expected 1, 1 and 2", summary.Created,
+			summary.Existing, summary.Errored)
+	}
+	if len(summary.Users) != len(expected) {
+		t.Fatalf("got %d results, expected %d", len(summary.Users), len(expected))
+	}
+	for i, result := range summary.Users {
+		if result.Username != expected[i].username || result.Result != expected[i].result {
+			t.Errorf("got %s %s, expected %s %s", result.Username, result.Result, expected[i].username,
+				expected[i].result)
+		}
+		if (result.Result == CreateResultError) != (result.Error != "") {
+			t.Errorf("user %s is %s with error '%s'", result.Username, result.Result, result.Error)
+		}
+		if (result.Result == CreateResultCreated) != (result.ID != "") {
+			t.Errorf("user %s is %s with ID '%s'", result.Username, result.Result, result.ID)
+		}
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	if server.Sites[""].User("john.smith") == nil {
+		t.Errorf("created user doesn't exist on the server")
+	}
+	if server.Sites[""].User("carl") != nil {
+		t.Errorf("user was created despite failure")
+	}
+}
@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// DeleteGroup deletes group from the site; users in the group are not deleted.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#delete_group
+// API Endpoint: DELETE /api/api-version/sites/site-id/groups/group-id
+func (t *Tableau) DeleteGroup(groupID string) (bool, error) {
+	deleteGroupURL := t.siteURL("groups/%s", groupID)
+
+	log.Debugf("Deleting group %s on URL %s", groupID, deleteGroupURL)
+
+	if err := t.do(http.MethodDelete, deleteGroupURL, nil, nil, http.StatusNoContent); err != nil {
+		return false, fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// DeleteProject deletes project from the site, together with its nested projects and all their content.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#delete_project
+// API Endpoint: DELETE /api/api-version/sites/site-id/projects/project-id
+func (t *Tableau) DeleteProject(projectID string) (bool, error) {
+	deleteProjectURL := t.siteURL("projects/%s", projectID)
+
+	log.Debugf("Deleting project %s on URL %s", projectID, deleteProjectURL)
+
+	if err := t.do(http.MethodDelete, deleteProjectURL, nil, nil, http.StatusNoContent); err != nil {
+		return false, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"testing"
+)
+
+func TestGetAssetsUser(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	site.AddUser(tableautest.User{Name: "john.smith", SiteRole: "Creator"})
+	site.AddUser(tableautest.User{Name: "jane.doe", SiteRole: "Viewer"})
+
+	tests := []struct {
+		name     string
+		username string
+		wantUser bool
+		wantErr  bool
+	}{
+		{name: "user who can own content", username: "john.smith", wantUser: true},
+		{name: "user who can't own content", username: "jane.doe", wantUser: true, wantErr: true},
+		{name: "user who doesn't exist", username: "nobody", wantUser: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := tableau.GetAssetsUser(tt.username)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, expected error: %t", err, tt.wantErr)
+			}
+			if (user != nil) != tt.wantUser {
+				t.Errorf("got user %+v, expected user: %t", user, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	creator := site.AddUser(tableautest.User{Name: "john.smith", SiteRole: "Creator"})
+	viewer := site.AddUser(tableautest.User{Name: "jane.doe", SiteRole: "Viewer"})
+	leaving := site.AddUser(tableautest.User{Name: "bob.x", SiteRole: "Explorer"})
+	workbook := site.AddContent("workbooks", "Sales", leaving.ID)
+
+	// The server refuses to move assets to a user who can't own content, and the user is not deleted.
+	if _, err := tableau.DeleteUser(leaving.ID, viewer.ID); !HasStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("got error %v, expected status %d", err, http.StatusBadRequest)
+	}
+	server.Lock()
+	if site.User("bob.x") == nil {
+		t.Errorf("user was deleted despite failure")
+	}
+	server.Unlock()
+
+	if _, err := tableau.DeleteUser(leaving.ID, creator.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	if site.User("bob.x") != nil {
+		t.Errorf("user was not deleted")
+	}
+	if workbook.OwnerID != creator.ID {
+		t.Errorf("workbook is owned by %s, expected %s", workbook.OwnerID, creator.ID)
+	}
+}
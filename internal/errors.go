@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSessionExpired matches TableauError of rejected token of expired session, see TableauError.Is.
+var ErrSessionExpired = errors.New("session expired")
+
+// Tableau error codes, see https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_errors.htm
+const (
+	// SessionExpiredErrorCode is returned with 401 status when authentication token is invalid or expired.
+	SessionExpiredErrorCode = "401002"
+	// UserExistsErrorCode is returned with 409 status when user being added to a site already exists.
+	UserExistsErrorCode = "409017"
+	// GroupExistsErrorCode is returned with 409 status when group being created already exists.
+	GroupExistsErrorCode = "409009"
+	// MemberExistsErrorCode is returned with 409 status when user being added to a group is already its member.
+	MemberExistsErrorCode = "409011"
+	// MemberNotFoundErrorCode is returned with 404 status when user being removed from a group isn't its member; it's
+	// the code of user not found, unlike 404011 of group not found.
+	MemberNotFoundErrorCode = "404002"
+	// ProjectExistsErrorCode is returned with 409 status when project with the same name already exists in the parent
+	// project.
+	ProjectExistsErrorCode = "409006"
+)
+
+// TableauError is returned by all calls for unexpected server response.
+// Code, Summary and Detail are empty if the response doesn't contain Tableau error, e.g. from a proxy.
+type TableauError struct {
+	StatusCode int
+	Code       string
+	Summary    string
+	Detail     string
+}
+
+func (e *TableauError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("server responded with status code: %d - %s", e.StatusCode, e.Detail)
+	}
+	return fmt.Sprintf("server responded with status code: %d, Code: %s, Summary: %s, Detail: %s", e.StatusCode,
+		e.Code, e.Summary, e.Detail)
+}
+
+// Is makes errors.Is(err, ErrSessionExpired) match expired session error.
+func (e *TableauError) Is(target error) bool {
+	return target == ErrSessionExpired && e.Code == SessionExpiredErrorCode
+}
+
+// AsTableauError returns TableauError from err chain, if there is one.
+func AsTableauError(err error) (*TableauError, bool) {
+	var tableauErr *TableauError
+	if errors.As(err, &tableauErr) {
+		return tableauErr, true
+	}
+	return nil, false
+}
+
+// HasErrorCode returns true if err is TableauError with given Tableau error code, e.g. UserExistsErrorCode.
+func HasErrorCode(err error, code string) bool {
+	tableauErr, ok := AsTableauError(err)
+	return ok && tableauErr.Code == code
+}
+
+// HasStatusCode returns true if err is TableauError with given HTTP status code.
+func HasStatusCode(err error, statusCode int) bool {
+	tableauErr, ok := AsTableauError(err)
+	return ok && tableauErr.StatusCode == statusCode
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+)
+
+type GetGroupResponse struct {
+	XMLName    xml.Name                `xml:"tsResponse"`
+	Pagination Pagination              `xml:"pagination"`
+	Groups     []GetGroupResponseGroup `xml:"groups>group"`
+}
+
+type GetGroupResponseGroup struct {
+	ID               string `xml:"id,attr"`
+	Name             string `xml:"name,attr"`
+	MinimumSiteRole  string `xml:"minimumSiteRole,attr"`
+	GrantLicenseMode string `xml:"grantLicenseMode,attr"`
+	Domain           struct {
+		Name string `xml:"name,attr"`
+	} `xml:"domain"`
+	Import *GroupImport `xml:"import"`
+}
+
+// toGroup converts group from server response to existing Group.
+func (g GetGroupResponseGroup) toGroup() *Group {
+	group := &Group{
+		Exists:           true,
+		Name:             g.Name,
+		ID:               g.ID,
+		Domain:           g.Domain.Name,
+		MinimumSiteRole:  g.MinimumSiteRole,
+		GrantLicenseMode: g.GrantLicenseMode,
+	}
+	// Older API versions tell minimum site role and grant license mode of imported groups only in the import element.
+	if g.Import != nil {
+		if group.MinimumSiteRole == "" {
+			group.MinimumSiteRole = g.Import.SiteRole
+		}
+		if group.GrantLicenseMode == "" {
+			group.GrantLicenseMode = g.Import.GrantLicenseMode
+		}
+	}
+	return group
+}
+
+// GetGroup returns group with given name. Returns Group with Exists set to false if there is no such group.
+// Returns non-nil error if fails to get the group, or more than one group matches the name.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#query_groups
+// API Endpoint: GET /api/api-version/sites/site-id/groups?filter=name:eq:group-name
+func (t *Tableau) GetGroup(name string) (*Group, error) {
+	searchGroupURL := t.siteURL("groups?filter=%s", url.QueryEscape(Filter("name", "eq", name)))
+
+	log.Debugf("Searching for group on %s", searchGroupURL)
+
+	var getGroupResponse GetGroupResponse
+	if err := t.do(http.MethodGet, searchGroupURL, nil, &getGroupResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	if len(getGroupResponse.Groups) == 0 {
+		return &Group{Exists: false}, nil
+	}
+	if len(getGroupResponse.Groups) > 1 {
+		return &Group{Exists: false}, fmt.Errorf("ambiguous result - more than one group returned")
+	}
+
+	return getGroupResponse.Groups[0].toGroup(), nil
+}
+
+// Groups returns iterator of groups in given site matching the query - see Users.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#query_groups
+// API Endpoint: GET /api/api-version/sites/site-id/groups?filter=filter-expression&sort=sort-expression
+func (t *Tableau) Groups(query ListQuery) *Iterator[*Group] {
+	return list(t, "groups", query, func(response *GetGroupResponse) ([]*Group, Pagination) {
+		groups := make([]*Group, 0, len(response.Groups))
+		for _, group := range response.Groups {
+			groups = append(groups, group.toGroup())
+		}
+		return groups, response.Pagination
+	})
+}
+
+// GetGroups returns list of all groups in given site matching the query - see Groups.
+func (t *Tableau) GetGroups(query ListQuery) ([]*Group, error) {
+	return collect(t.Groups(query), "groups")
+}
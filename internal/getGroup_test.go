@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"testing"
+)
+
+func TestGetGroup(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+	server.Sites[""].AddGroup(tableautest.Group{Name: "Finance", Domain: "example.com", MinimumSiteRole: "Viewer",
+		GrantLicenseMode: "onSync"})
+
+	group, err := tableau.GetGroup("Finance")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := Group{Name: "Finance", ID: group.ID, Domain: "example.com", MinimumSiteRole: "Viewer",
+		GrantLicenseMode: "onSync", Exists: true}
+	if *group != expected || group.ID == "" {
+		t.Errorf("got %+v, expected %+v", group, expected)
+	}
+
+	group, err = tableau.GetGroup("Marketing")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if group.Exists {
+		t.Errorf("got %+v, expected group which doesn't exist", group)
+	}
+}
+
+func TestGroupsPagination(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	for i := 1; i <= 12; i++ {
+		site.AddGroup(tableautest.Group{Name: fmt.Sprintf("group%02d", i)})
+	}
+
+	groups, err := tableau.GetGroups(ListQuery{Filters: []string{Filter("name", "has", "group")},
+		Sort: []string{"name:desc"}, PageSize: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(groups) != 12 {
+		t.Fatalf("got %d groups, expected 12", len(groups))
+	}
+	for i, group := range groups {
+		if expected := fmt.Sprintf("group%02d", 12-i); group.Name != expected {
+			t.Errorf("group %d is %s, expected %s", i, group.Name, expected)
+		}
+	}
+	if n := server.Requests[fmt.Sprintf("%s /sites/%s/groups", http.MethodGet, tableau.SiteID)]; n != 3 {
+		t.Errorf("fetched %d pages, expected 3", n)
+	}
+}
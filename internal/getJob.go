@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type JobResponse struct {
+	XMLName xml.Name       `xml:"tsResponse"`
+	Job     JobResponseJob `xml:"job"`
+}
+
+type JobResponseJob struct {
+	ID          string `xml:"id,attr"`
+	Type        string `xml:"type,attr"`
+	Progress    int    `xml:"progress,attr"`
+	CreatedAt   string `xml:"createdAt,attr"`
+	StartedAt   string `xml:"startedAt,attr"`
+	CompletedAt string `xml:"completedAt,attr"`
+	FinishCode  int    `xml:"finishCode,attr"`
+	Notes       []struct {
+		Text string `xml:"text,attr"`
+	} `xml:"statusNotes>statusNote"`
+}
+
+// toJob converts job from server response to Job.
+func (j JobResponseJob) toJob() *Job {
+	notes := make([]string, 0, len(j.Notes))
+	for _, note := range j.Notes {
+		notes = append(notes, note.Text)
+	}
+	return &Job{
+		ID:          j.ID,
+		Type:        j.Type,
+		Progress:    j.Progress,
+		CreatedAt:   j.CreatedAt,
+		StartedAt:   j.StartedAt,
+		CompletedAt: j.CompletedAt,
+		FinishCode:  j.FinishCode,
+		Notes:       strings.Join(notes, "; "),
+	}
+}
+
+// GetJob returns job with given ID.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_jobs_tasks_and_schedules.htm#query_job
+// API Endpoint: GET /api/api-version/sites/site-id/jobs/job-id
+func (t *Tableau) GetJob(jobID string) (*Job, error) {
+	getJobURL := t.siteURL("jobs/%s", jobID)
+
+	log.Debugf("Getting job on %s", getJobURL)
+
+	var jobResponse JobResponse
+	if err := t.do(http.MethodGet, getJobURL, nil, &jobResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return jobResponse.Job.toJob(), nil
+}
+
+// WaitForJob asks the server about the job every JobPollInterval until it completes, and returns the completed job.
+// Returns error if fails to get the job, or if the job doesn't complete within timeout - the job keeps running on the
+// server; failure of the job itself is told by its FinishCode.
+func (t *Tableau) WaitForJob(jobID string, timeout time.Duration) (*Job, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := t.GetJob(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.CompletedAt != "" {
+			return job, nil
+		}
+		if !time.Now().Add(JobPollInterval).Before(deadline) {
+			return job, fmt.Errorf("job %s did not complete within %s - it is %d%% done", jobID, timeout,
+				job.Progress)
+		}
+		log.Infof("Job %s is %d%% done", jobID, job.Progress)
+		time.Sleep(JobPollInterval)
+	}
+}
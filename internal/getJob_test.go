@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"testing"
+	"time"
+)
+
+func TestWaitForJob(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	group := site.AddGroup(tableautest.Group{Name: "Sales", Domain: "example.com", MinimumSiteRole: "Viewer"})
+	running := &tableautest.Job{ID: "running-job", Type: GroupSyncJobType, CreatedAt: "2026-01-01T00:00:00Z"}
+	site.Jobs = append(site.Jobs, running)
+
+	started, err := tableau.SyncGroup(Group{Name: group.Name, ID: group.ID, Domain: group.Domain})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	job, err := tableau.WaitForJob(started.ID, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if job.CompletedAt == "" || job.FinishCode != 0 {
+		t.Errorf("got job %+v, expected successfully completed job", job)
+	}
+
+	// Timeout shorter than the poll interval gives up after the first check.
+	job, err = tableau.WaitForJob(running.ID, time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected timeout error")
+	}
+	if job == nil || job.ID != running.ID || job.CompletedAt != "" {
+		t.Errorf("got job %+v, expected the running job", job)
+	}
+
+	if _, err := tableau.WaitForJob("missing-job", time.Minute); err == nil {
+		t.Errorf("expected error of missing job")
+	}
+}
@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+type GetProjectResponse struct {
+	XMLName    xml.Name                    `xml:"tsResponse"`
+	Pagination Pagination                  `xml:"pagination"`
+	Projects   []GetProjectResponseProject `xml:"projects>project"`
+}
+
+type GetProjectResponseProject struct {
+	ID                 string `xml:"id,attr"`
+	Name               string `xml:"name,attr"`
+	Description        string `xml:"description,attr"`
+	ParentProjectID    string `xml:"parentProjectId,attr"`
+	ContentPermissions string `xml:"contentPermissions,attr"`
+}
+
+// toProject converts project from server response to existing Project.
+func (p GetProjectResponseProject) toProject() *Project {
+	return &Project{
+		Exists:             true,
+		Name:               p.Name,
+		ID:                 p.ID,
+		Description:        p.Description,
+		ParentID:           p.ParentProjectID,
+		ContentPermissions: p.ContentPermissions,
+	}
+}
+
+// Projects returns iterator of projects in given site matching the query - see Users.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#query_projects
+// API Endpoint: GET /api/api-version/sites/site-id/projects?filter=filter-expression&sort=sort-expression
+func (t *Tableau) Projects(query ListQuery) *Iterator[*Project] {
+	return list(t, "projects", query, func(response *GetProjectResponse) ([]*Project, Pagination) {
+		projects := make([]*Project, 0, len(response.Projects))
+		for _, project := range response.Projects {
+			projects = append(projects, project.toProject())
+		}
+		return projects, response.Pagination
+	})
+}
+
+// GetProjects returns list of all projects in given site matching the query - see Projects.
+func (t *Tableau) GetProjects(query ListQuery) ([]*Project, error) {
+	return collect(t.Projects(query), "projects")
+}
+
+// GetProjectTree returns all projects of the site arranged into tree - see ProjectTree.
+func (t *Tableau) GetProjectTree() ([]*Project, error) {
+	projects, err := t.GetProjects(ListQuery{})
+	if err != nil {
+		return nil, err
+	}
+	return ProjectTree(projects), nil
+}
+
+// GetProject returns project with given path, e.g. Finance/Reports. Returns Project with Exists set to false if there
+// is no such project. Projects are looked up in the tree of all projects of the site - see GetProjectTree.
+func (t *Tableau) GetProject(path string) (*Project, error) {
+	tree, err := t.GetProjectTree()
+	if err != nil {
+		return nil, err
+	}
+	if project := FindProject(tree, path); project != nil {
+		return project, nil
+	}
+	return &Project{Exists: false, Path: path}, nil
+}
+
+// ProjectTree returns projects in depth-first order of their tree, with siblings sorted by name, and sets their Path
+// and Level. Projects whose parent is not among the projects are treated as top-level ones.
+func ProjectTree(projects []*Project) []*Project {
+	byID := make(map[string]*Project, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+
+	children := make(map[string][]*Project)
+	for _, project := range projects {
+		parentID := project.ParentID
+		if _, ok := byID[parentID]; !ok {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], project)
+	}
+
+	tree := make([]*Project, 0, len(projects))
+	var walk func(parent *Project)
+	walk = func(parent *Project) {
+		parentID := ""
+		if parent != nil {
+			parentID = parent.ID
+		}
+		siblings := children[parentID]
+		sort.SliceStable(siblings, func(i, j int) bool {
+			return strings.ToLower(siblings[i].Name) < strings.ToLower(siblings[j].Name)
+		})
+		for _, project := range siblings {
+			project.Path, project.Level = project.Name, 0
+			if parent != nil {
+				project.Path, project.Level = parent.Path+ProjectPathSeparator+project.Name, parent.Level+1
+			}
+			tree = append(tree, project)
+			walk(project)
+		}
+	}
+	walk(nil)
+
+	return tree
+}
+
+// FindProject returns project with given path (case-insensitive) from projects arranged into tree, or nil.
+func FindProject(tree []*Project, path string) *Project {
+	path = strings.Trim(path, ProjectPathSeparator)
+	for _, project := range tree {
+		if strings.EqualFold(project.Path, path) {
+			return project
+		}
+	}
+	return nil
+}
+
+// SubTree returns the project and its descendants from projects arranged into tree.
+func SubTree(tree []*Project, project *Project) []*Project {
+	var res []*Project
+	for idx, p := range tree {
+		if p != project {
+			continue
+		}
+		res = append(res, p)
+		for _, descendant := range tree[idx+1:] {
+			if descendant.Level <= project.Level {
+				break
+			}
+			res = append(res, descendant)
+		}
+	}
+	return res
+}
+
+// SplitProjectPath returns path of the parent project, which is empty for top-level project, and name of the project.
+func SplitProjectPath(path string) (string, string) {
+	path = strings.Trim(path, ProjectPathSeparator)
+	idx := strings.LastIndex(path, ProjectPathSeparator)
+	if idx < 0 {
+		return "", path
+	}
+	return path[:idx], path[idx+1:]
+}
+
+// Indent returns indentation of the project in tree by its level, used by tree output.
+func (p *Project) Indent() string {
+	return strings.Repeat("  ", p.Level)
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"testing"
+)
+
+// testProjects returns projects in server order:
+//
+//	Default
+//	Finance
+//	  Archive
+//	  Reports
+//	    2024
+//	Marketing
+func testProjects() []*Project {
+	return []*Project{
+		{ID: "reports", Name: "Reports", ParentID: "finance"},
+		{ID: "marketing", Name: "Marketing"},
+		{ID: "2024", Name: "2024", ParentID: "reports"},
+		{ID: "finance", Name: "Finance"},
+		{ID: "archive", Name: "archive", ParentID: "finance"},
+		{ID: "default", Name: "Default"},
+	}
+}
+
+func TestProjectTree(t *testing.T) {
+	tree := ProjectTree(testProjects())
+
+	expected := []struct {
+		path  string
+		level int
+	}{
+		{"Default", 0},
+		{"Finance", 0},
+		{"Finance/archive", 1},
+		{"Finance/Reports", 1},
+		{"Finance/Reports/2024", 2},
+		{"Marketing", 0},
+	}
+
+	if len(tree) != len(expected) {
+		t.Fatalf("got %d projects, expected %d", len(tree), len(expected))
+	}
+	for i, project := range tree {
+		if project.Path != expected[i].path || project.Level != expected[i].level {
+			t.Errorf("project %d is %s at level %d, expected %s at level %d", i, project.Path, project.Level,
+				expected[i].path, expected[i].level)
+		}
+	}
+}
+
+func TestProjectTreeWithMissingParent(t *testing.T) {
+	tree := ProjectTree([]*Project{{ID: "orphan", Name: "Orphan", ParentID: "not-visible"}})
+
+	if len(tree) != 1 || tree[0].Path != "Orphan" || tree[0].Level != 0 {
+		t.Errorf("project with missing parent is not top-level: %+v", tree[0])
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	tree := ProjectTree(testProjects())
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "Finance", expected: "finance"},
+		{path: "finance/reports/2024", expected: "2024"},
+		{path: "/Finance/Reports/", expected: "reports"},
+		{path: "Reports", expected: ""},
+		{path: "Finance/Missing", expected: ""},
+		{path: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			project := FindProject(tree, tt.path)
+			switch {
+			case tt.expected == "" && project != nil:
+				t.Errorf("got %s, expected no project", project.Path)
+			case tt.expected != "" && (project == nil || project.ID != tt.expected):
+				t.Errorf("got %v, expected project %s", project, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubTree(t *testing.T) {
+	tree := ProjectTree(testProjects())
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "Finance", expected: []string{"finance", "archive", "reports", "2024"}},
+		{path: "Finance/Reports", expected: []string{"reports", "2024"}},
+		{path: "Finance/Reports/2024", expected: []string{"2024"}},
+		{path: "Marketing", expected: []string{"marketing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			subTree := SubTree(tree, FindProject(tree, tt.path))
+
+			var got []string
+			for _, project := range subTree {
+				got = append(got, project.ID)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("got %v, expected %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("got %v, expected %v", got, tt.expected)
+				}
+			}
+		})
+	}
+
+	if subTree := SubTree(tree, &Project{ID: "finance"}); len(subTree) != 0 {
+		t.Errorf("got %d projects for project which is not in the tree", len(subTree))
+	}
+}
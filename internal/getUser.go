@@ -4,9 +4,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
-	"io"
 	"net/http"
+	"net/url"
 )
 
 type GetUserResponse struct {
@@ -16,10 +15,16 @@ type GetUserResponse struct {
 }
 
 type GetUserResponseUser struct {
-	ID          string `xml:"id,attr"`
-	Name        string `xml:"name,attr"`
-	SiteRole    string `xml:"siteRole,attr"`
-	AuthSetting string `xml:"authSetting,attr"`
+	ID                 string `xml:"id,attr"`
+	Name               string `xml:"name,attr"`
+	SiteRole           string `xml:"siteRole,attr"`
+	AuthSetting        string `xml:"authSetting,attr"`
+	Email              string `xml:"email,attr"`
+	FullName           string `xml:"fullName,attr"`
+	LastLogin          string `xml:"lastLogin,attr"`
+	ExternalAuthUserID string `xml:"externalAuthUserId,attr"`
+	Locale             string `xml:"locale,attr"`
+	Language           string `xml:"language,attr"`
 }
 
 // Pagination : <pagination pageNumber="1" pageSize="100" totalAvailable="341"/>
@@ -29,6 +34,23 @@ type Pagination struct {
 	TotalAvailable int `xml:"totalAvailable,attr"`
 }
 
+// toUser converts user from server response to existing User.
+func (u GetUserResponseUser) toUser() *User {
+	return &User{
+		Exists:             true,
+		Username:           u.Name,
+		ID:                 u.ID,
+		Role:               u.SiteRole,
+		AuthSetting:        u.AuthSetting,
+		Email:              u.Email,
+		FullName:           u.FullName,
+		LastLogin:          u.LastLogin,
+		ExternalAuthUserID: u.ExternalAuthUserID,
+		Locale:             u.Locale,
+		Language:           u.Language,
+	}
+}
+
 // GetUser - Get user object from the server.
 // Returns non-nil err object if
 // - fails to construct request or client
@@ -39,52 +61,14 @@ type Pagination struct {
 // Returns User object on success.
 // API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_users_on_site
 // API Endpoint: GET /api/api-version/sites/site-id/users?filter=filter-expression
-func (t Tableau) GetUser(username string) (*User, error) {
-	searchUserURL := fmt.Sprintf("%s/sites/%s/users/?filter=name:eq:%s", t.BaseURL, t.SiteID, username)
+func (t *Tableau) GetUser(username string) (*User, error) {
+	searchUserURL := t.siteURL("users/?filter=name:eq:%s", url.QueryEscape(username))
 
 	log.Debugf("Searching for user on %s", searchUserURL)
 
-	req, err := http.NewRequest(http.MethodGet, searchUserURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create get_user request: %w", err)
-	}
-
-	req.Header.Set("X-Tableau-Auth", t.Token)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send get_user request: %w", err)
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			panic(err)
-		}
-	}(resp.Body)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	log.Debugf("response body: %s", string(body))
-	log.Debugf("response code: %d", resp.StatusCode)
-
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusUnauthorized {
-			password := viper.GetString("tableau_password")
-			return nil, fmt.Errorf("invalid credentials [%d] (%s:%s)", resp.StatusCode,
-				viper.GetString("tableau_username"),
-				fmt.Sprintf("%s*****%s", password[0:1], password[len(password)-1:]))
-		}
-		return nil, fmt.Errorf("failed to get user - server responded with status code: %d - %s", resp.StatusCode, string(body))
-	}
-
 	var getUserResponse GetUserResponse
-	if err := xml.Unmarshal(body, &getUserResponse); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal response body: %w", err)
+	if err := t.do(http.MethodGet, searchUserURL, nil, &getUserResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	if len(getUserResponse.Users) == 0 {
@@ -94,93 +78,27 @@ func (t Tableau) GetUser(username string) (*User, error) {
 		return &User{Exists: false}, fmt.Errorf("ambiguous result - more than one user returned")
 	}
 
-	user := getUserResponse.Users[0]
-	return &User{
-		Exists:   true,
-		Username: user.Name,
-		ID:       user.ID,
-		Role:     user.SiteRole,
-	}, nil
+	return getUserResponse.Users[0].toUser(), nil
 }
 
-// GetUsers returns list of all users in given site.
+// Users returns iterator of users in given site matching the query, fetching up to Concurrency pages in parallel.
 // API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_users_on_site
-// API Endpoint: GET /api/api-version/sites/site-id/users
-func (t Tableau) GetUsers() ([]*User, error) {
-	done := false
-	pageNumber := 1
-	pageSize := 100
-	res := make([]*User, 0)
-
-	for !done {
-		url := fmt.Sprintf("%s/sites/%s/users?pageSize=%d&pageNumber=%d", t.BaseURL, t.SiteID, pageSize, pageNumber)
-
-		log.Debugf("Fetching %d users/page %d from %s", pageSize, pageNumber, url)
-
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create get_users request: %w", err)
-		}
-
-		req.Header.Set("X-Tableau-Auth", t.Token)
-
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to send get_users request: %w", err)
-		}
-
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				panic(err)
-			}
-		}(resp.Body)
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
-
-		log.Debugf("response body: %s", string(body))
-		log.Debugf("response code: %d", resp.StatusCode)
-
-		if resp.StatusCode != http.StatusOK {
-			if resp.StatusCode == http.StatusUnauthorized {
-				password := viper.GetString("tableau_password")
-				return nil, fmt.Errorf("invalid credentials [%d] (%s:%s)", resp.StatusCode,
-					viper.GetString("tableau_username"),
-					fmt.Sprintf("%s*****%s", password[0:1], password[len(password)-1:]))
-			}
-			return nil, fmt.Errorf("failed to get user - server responded with status code: %d - %s", resp.StatusCode, string(body))
-		}
-
-		var getUserResponse GetUserResponse
-		if err := xml.Unmarshal(body, &getUserResponse); err != nil {
-			return nil, fmt.Errorf("unable to unmarshal response body: %w", err)
-		}
-
-		users := getUserResponse.Users
-
-		if len(users) == 0 {
-			log.Info("No users were found")
-			return []*User{}, nil
-		}
-
-		log.Debugf("Server returned %d users.", len(users))
-
-		for _, user := range users {
-			res = append(res, &User{
-				Exists:   true,
-				Username: user.Name,
-				ID:       user.ID,
-				Role:     user.SiteRole,
-			})
+// API Endpoint: GET /api/api-version/sites/site-id/users?filter=filter-expression&sort=sort-expression&fields=field-expression
+func (t *Tableau) Users(query ListQuery) *Iterator[*User] {
+	return list(t, "users", query, func(response *GetUserResponse) ([]*User, Pagination) {
+		users := make([]*User, 0, len(response.Users))
+		for _, user := range response.Users {
+			users = append(users, user.toUser())
 		}
+		return users, response.Pagination
+	})
+}
 
-		done = len(res) >= getUserResponse.Pagination.TotalAvailable
-		pageNumber++
+// GetUsers returns list of all users in given site matching the query - see Users.
+func (t *Tableau) GetUsers(query ListQuery) ([]*User, error) {
+	res, err := collect(t.Users(query), "users")
+	if err == nil && len(res) == 0 {
+		log.Info("No users were found")
 	}
-
-	return res, nil
+	return res, err
 }
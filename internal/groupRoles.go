@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GroupRole is a rule requiring members of the group to have at least the site role.
+type GroupRole struct {
+	Group string `json:"group" yaml:"group"`
+	Role  string `json:"role" yaml:"role"`
+}
+
+// ReadGroupRolesFile reads list of group roles from YAML file with keys group and role. Every group can be listed
+// only once, and roles are validated - see ValidateSiteRole.
+func ReadGroupRolesFile(path string) ([]GroupRole, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open group roles file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var rules []GroupRole
+	if err := yaml.NewDecoder(file).Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse group roles file %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool, len(rules))
+	for idx := range rules {
+		rule := &rules[idx]
+		if strings.TrimSpace(rule.Group) == "" {
+			return nil, fmt.Errorf("failed to parse group roles file %s: rule %d has no group", path, idx+1)
+		}
+		if seen[strings.ToLower(rule.Group)] {
+			return nil, fmt.Errorf("failed to parse group roles file %s: group %s is listed more than once", path,
+				rule.Group)
+		}
+		seen[strings.ToLower(rule.Group)] = true
+
+		if rule.Role, err = ValidateSiteRole(rule.Role); err != nil {
+			return nil, fmt.Errorf("failed to parse group roles file %s: group %s: %w", path, rule.Group, err)
+		}
+	}
+
+	return rules, nil
+}
+
+// PlanGroupRoles resolves members of groups of the rules, and returns plan of updates of users whose site role is
+// lower than roles required by groups they're member of - see RaiseSiteRole. Roles are never lowered, administrator
+// rights are kept, and users with unknown role or who aren't member of any of the groups are left as they are. Group
+// whose role raised the user's role last is set in the steps.
+// Returns non-nil error if any of the groups doesn't exist, or fails to get groups or their members.
+func (t *Tableau) PlanGroupRoles(rules []GroupRole) (*UsersPlan, error) {
+	type required struct {
+		user  *User
+		role  string
+		group string
+	}
+	users := make(map[string]*required)
+	var order []string
+
+	for _, rule := range rules {
+		group, err := t.GetGroup(rule.Group)
+		if err != nil {
+			return nil, err
+		}
+		if !group.Exists {
+			return nil, fmt.Errorf("group %s does not exist", rule.Group)
+		}
+
+		members, err := t.GetGroupUsers(group.ID, ListQuery{})
+		if err != nil {
+			return nil, err
+		}
+		log.Debugf("Group %s has %d members, who require role %s", group.Name, len(members), rule.Role)
+
+		for _, member := range members {
+			r, ok := users[member.ID]
+			if !ok {
+				r = &required{user: member, role: member.Role}
+				users[member.ID] = r
+				order = append(order, member.ID)
+			}
+			role, known := RaiseSiteRole(r.role, rule.Role)
+			if !known {
+				if !ok {
+					log.Warnf("User %s has unknown site role %s - leaving it as it is", member.Username,
+						member.Role)
+				}
+				continue
+			}
+			if role != r.role {
+				r.role, r.group = role, group.Name
+			}
+		}
+	}
+
+	plan := &UsersPlan{Steps: make([]UsersPlanStep, 0)}
+	for _, id := range order {
+		r := users[id]
+		if strings.EqualFold(r.role, r.user.Role) {
+			plan.Unchanged++
+			continue
+		}
+		plan.Steps = append(plan.Steps, UsersPlanStep{Action: PlanUpdate, Username: r.user.Username, ID: r.user.ID,
+			Role: r.role, PreviousRole: r.user.Role, Group: r.group})
+	}
+	sort.Slice(plan.Steps, func(i, j int) bool {
+		return strings.ToLower(plan.Steps[i].Username) < strings.ToLower(plan.Steps[j].Username)
+	})
+	plan.Update = len(plan.Steps)
+
+	return plan, nil
+}
+
+// ApplyGroupRoles updates site roles of users in the plan - see PlanGroupRoles and UpdateUserSiteRole - and records
+// result of every step. Failed updates don't stop the rest; returns number of failed updates.
+func (t *Tableau) ApplyGroupRoles(plan *UsersPlan) int {
+	plan.Applied = true
+
+	for idx := range plan.Steps {
+		step := &plan.Steps[idx]
+
+		user, err := t.UpdateUserSiteRole(step.Username, step.Role)
+		if err == nil && !user.Exists {
+			err = fmt.Errorf("user does not exist anymore")
+		}
+
+		if err != nil {
+			plan.Errored++
+			step.Result = PlanResultError
+			step.Error = err.Error()
+			log.Errorf("[%d/%d] Failed to update user %s to role %s: %s", idx+1, len(plan.Steps), step.Username,
+				step.Role, err)
+		} else {
+			step.Result = PlanResultDone
+			log.Infof("[%d/%d] User %s updated from role %s to role %s", idx+1, len(plan.Steps), step.Username,
+				step.PreviousRole, step.Role)
+		}
+	}
+
+	return plan.Errored
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"reflect"
+	"testing"
+)
+
+func TestPlanGroupRoles(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	john := site.AddUser(tableautest.User{Name: "john.smith", SiteRole: "Viewer"})
+	jane := site.AddUser(tableautest.User{Name: "jane.doe", SiteRole: "Creator"})
+	bob := site.AddUser(tableautest.User{Name: "bob.x", SiteRole: "SiteAdministratorExplorer"})
+	carl := site.AddUser(tableautest.User{Name: "carl", SiteRole: "Viewer"})
+	site.AddGroup(tableautest.Group{Name: "Analysts", UserIDs: []string{john.ID, jane.ID, bob.ID}})
+	site.AddGroup(tableautest.Group{Name: "Publishers", UserIDs: []string{john.ID, bob.ID}})
+	site.AddGroup(tableautest.Group{Name: "Readers", UserIDs: []string{carl.ID}})
+
+	tests := []struct {
+		name      string
+		rules     []GroupRole
+		expected  []string
+		unchanged int
+		wantErr   bool
+	}{
+		{
+			name:  "user in two groups gets the highest role",
+			rules: []GroupRole{{Group: "Analysts", Role: "Explorer"}, {Group: "Publishers", Role: "Creator"}},
+			expected: []string{"bob.x SiteAdministratorExplorer SiteAdministratorCreator Publishers",
+				"john.smith Viewer Creator Publishers"},
+			unchanged: 1,
+		},
+		{
+			name:  "group of lower role doesn't lower role raised by other group",
+			rules: []GroupRole{{Group: "Publishers", Role: "Creator"}, {Group: "Analysts", Role: "Explorer"}},
+			expected: []string{"bob.x SiteAdministratorExplorer SiteAdministratorCreator Publishers",
+				"john.smith Viewer Creator Publishers"},
+			unchanged: 1,
+		},
+		{
+			name:      "members already having the role",
+			rules:     []GroupRole{{Group: "Readers", Role: "Viewer"}},
+			unchanged: 1,
+		},
+		{
+			name:    "group which doesn't exist",
+			rules:   []GroupRole{{Group: "Analysts", Role: "Explorer"}, {Group: "Nobody", Role: "Creator"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tableau.PlanGroupRoles(tt.rules)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got plan %+v", plan)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, step := range plan.Steps {
+				if step.Action != PlanUpdate {
+					t.Errorf("got %s step, expected only updates", step.Action)
+				}
+				got = append(got, step.Username+" "+step.PreviousRole+" "+step.Role+" "+step.Group)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got steps %q, expected %q", got, tt.expected)
+			}
+			if plan.Unchanged != tt.unchanged || plan.Update != len(plan.Steps) {
+				t.Errorf("got %d unchanged and %d updated users, expected %d and %d", plan.Unchanged, plan.Update,
+					tt.unchanged, len(plan.Steps))
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type AddUserToGroupRequest struct {
+	XMLName xml.Name `xml:"tsRequest"`
+	User    struct {
+		ID string `xml:"id,attr"`
+	} `xml:"user"`
+}
+
+type AddUserToGroupResponse struct {
+	XMLName xml.Name            `xml:"tsResponse"`
+	User    GetUserResponseUser `xml:"user"`
+}
+
+// AddUserToGroup adds user to the group; both are identified by ID.
+// Returns error if the user is already member of the group - see MemberExistsErrorCode.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#add_user_to_group
+// API Endpoint: POST /api/api-version/sites/site-id/groups/group-id/users
+func (t *Tableau) AddUserToGroup(groupID, userID string) error {
+	addUserURL := t.siteURL("groups/%s/users", groupID)
+
+	log.Debugf("Adding user %s to group on %s", userID, addUserURL)
+
+	var payload AddUserToGroupRequest
+	payload.User.ID = userID
+
+	var response AddUserToGroupResponse
+	if err := t.do(http.MethodPost, addUserURL, payload, &response, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to add user to group: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveUserFromGroup removes user from the group; both are identified by ID.
+// Returns error if the user isn't member of the group - see MemberNotFoundErrorCode.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#remove_user_to_group
+// API Endpoint: DELETE /api/api-version/sites/site-id/groups/group-id/users/user-id
+func (t *Tableau) RemoveUserFromGroup(groupID, userID string) error {
+	removeUserURL := t.siteURL("groups/%s/users/%s", groupID, userID)
+
+	log.Debugf("Removing user from group on %s", removeUserURL)
+
+	if err := t.do(http.MethodDelete, removeUserURL, nil, nil, http.StatusNoContent); err != nil {
+		return fmt.Errorf("failed to remove user from group: %w", err)
+	}
+
+	return nil
+}
+
+// GroupUsers returns iterator of members of the group - see Users.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_users_in_group
+// API Endpoint: GET /api/api-version/sites/site-id/groups/group-id/users
+func (t *Tableau) GroupUsers(groupID string, query ListQuery) *Iterator[*User] {
+	return list(t, "groups/"+groupID+"/users", query, func(response *GetUserResponse) ([]*User, Pagination) {
+		users := make([]*User, 0, len(response.Users))
+		for _, user := range response.Users {
+			users = append(users, user.toUser())
+		}
+		return users, response.Pagination
+	})
+}
+
+// GetGroupUsers returns list of all members of the group - see GroupUsers.
+func (t *Tableau) GetGroupUsers(groupID string, query ListQuery) ([]*User, error) {
+	return collect(t.GroupUsers(groupID, query), "members of group")
+}
+
+// UserGroups returns iterator of groups the user is member of - see Users.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#get_groups_for_a_user
+// API Endpoint: GET /api/api-version/sites/site-id/users/user-id/groups
+func (t *Tableau) UserGroups(userID string, query ListQuery) *Iterator[*Group] {
+	return list(t, "users/"+userID+"/groups", query, func(response *GetGroupResponse) ([]*Group, Pagination) {
+		groups := make([]*Group, 0, len(response.Groups))
+		for _, group := range response.Groups {
+			groups = append(groups, group.toGroup())
+		}
+		return groups, response.Pagination
+	})
+}
+
+// GetUserGroups returns list of all groups the user is member of - see UserGroups.
+func (t *Tableau) GetUserGroups(userID string, query ListQuery) ([]*Group, error) {
+	return collect(t.UserGroups(userID, query), "groups of user")
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"testing"
+)
+
+func TestGroupMembers(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	john := site.AddUser(tableautest.User{Name: "john.smith"})
+	jane := site.AddUser(tableautest.User{Name: "jane.doe"})
+	group := site.AddGroup(tableautest.Group{Name: "Finance", UserIDs: []string{jane.ID}})
+
+	if err := tableau.AddUserToGroup(group.ID, john.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tableau.AddUserToGroup(group.ID, jane.ID); !HasErrorCode(err, MemberExistsErrorCode) {
+		t.Errorf("got error %v, expected %s", err, MemberExistsErrorCode)
+	}
+
+	members, err := tableau.GetGroupUsers(group.ID, ListQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(members) != 2 || members[0].Username != "jane.doe" || members[1].Username != "john.smith" {
+		t.Errorf("got members %+v, expected jane.doe and john.smith", members)
+	}
+
+	groups, err := tableau.GetUserGroups(john.ID, ListQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "Finance" {
+		t.Errorf("got groups %+v, expected Finance", groups)
+	}
+
+	if err := tableau.RemoveUserFromGroup(group.ID, jane.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tableau.RemoveUserFromGroup(group.ID, jane.ID); !HasErrorCode(err, MemberNotFoundErrorCode) {
+		t.Errorf("got error %v, expected %s", err, MemberNotFoundErrorCode)
+	}
+	if err := tableau.RemoveUserFromGroup("missing-group", john.ID); err == nil ||
+		HasErrorCode(err, MemberNotFoundErrorCode) {
+		t.Errorf("got error %v, expected error of missing group", err)
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	if len(group.UserIDs) != 1 || group.UserIDs[0] != john.ID {
+		t.Errorf("group has members %v, expected only %s", group.UserIDs, john.ID)
+	}
+}
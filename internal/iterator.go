@@ -0,0 +1,138 @@
+package internal
+
+// Iterator streams items of a paginated list. The first page is fetched by the first call of Next and tells how many
+// pages there are; the rest are fetched in parallel, at most concurrency pages at a time, and returned in order.
+// Only the pages being fetched or waiting to be consumed are kept in memory.
+//
+//	users := t.Users(query)
+//	defer users.Close()
+//	for users.Next() {
+//		user := users.Value()
+//		...
+//	}
+//	if err := users.Err(); err != nil {
+//		...
+//	}
+//
+// Iterator is not safe for use by multiple goroutines.
+type Iterator[T any] struct {
+	fetch       func(pageNumber int) ([]T, Pagination, error)
+	concurrency int
+
+	started bool
+	closed  bool
+	items   []T
+	current T
+	err     error
+	// pages receives channels of pages 2 and further, in order; each channel receives the page once it's fetched.
+	pages chan chan page[T]
+	done  chan struct{}
+}
+
+type page[T any] struct {
+	items []T
+	err   error
+}
+
+// newIterator returns iterator of pages returned by fetch; concurrency less than 1 is treated as 1.
+func newIterator[T any](concurrency int, fetch func(pageNumber int) ([]T, Pagination, error)) *Iterator[T] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Iterator[T]{fetch: fetch, concurrency: concurrency}
+}
+
+// Next advances to the next item, which is then available through Value. Returns false when there are no more
+// items or fetching of a page failed - see Err.
+func (it *Iterator[T]) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		items, pagination, err := it.fetch(1)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = items
+		it.start(pagination)
+	}
+
+	for len(it.items) == 0 {
+		if it.pages == nil {
+			return false
+		}
+		next, ok := <-it.pages
+		if !ok {
+			it.pages = nil
+			return false
+		}
+		p := <-next
+		if p.err != nil {
+			it.err = p.err
+			it.Close()
+			return false
+		}
+		it.items = p.items
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Value returns current item.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops fetching of further pages. It's safe to call Close more than once, and after the iteration finished.
+func (it *Iterator[T]) Close() {
+	if it.closed {
+		return
+	}
+	it.closed = true
+	if it.done != nil {
+		close(it.done)
+	}
+}
+
+// start fetches the rest of pages in background. A page is fetched only once there is room for it in pages,
+// which bounds both parallel requests and pages waiting to be consumed.
+func (it *Iterator[T]) start(first Pagination) {
+	if first.PageSize < 1 {
+		return
+	}
+	pageCount := (first.TotalAvailable + first.PageSize - 1) / first.PageSize
+	if pageCount <= 1 {
+		return
+	}
+
+	pages := make(chan chan page[T], it.concurrency-1)
+	done := make(chan struct{})
+	it.pages, it.done = pages, done
+
+	go func() {
+		defer close(pages)
+
+		for pageNumber := 2; pageNumber <= pageCount; pageNumber++ {
+			next := make(chan page[T], 1)
+			select {
+			case pages <- next:
+			case <-done:
+				return
+			}
+
+			go func(pageNumber int) {
+				items, _, err := it.fetch(pageNumber)
+				next <- page[T]{items: items, err: err}
+			}(pageNumber)
+		}
+	}()
+}
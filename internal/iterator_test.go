@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedFetch returns fetch of total items 0..total-1 in pages of pageSize. Later pages are returned sooner, so that
+// they complete out of order when fetched in parallel. Page failPage fails with errPage.
+func pagedFetch(total, pageSize, failPage int, fetched *int32) func(pageNumber int) ([]int, Pagination, error) {
+	pageCount := (total + pageSize - 1) / pageSize
+	return func(pageNumber int) ([]int, Pagination, error) {
+		atomic.AddInt32(fetched, 1)
+		time.Sleep(time.Duration(pageCount-pageNumber) * time.Millisecond)
+		if pageNumber == failPage {
+			return nil, Pagination{}, errPage
+		}
+
+		var items []int
+		for i := (pageNumber - 1) * pageSize; i < pageNumber*pageSize && i < total; i++ {
+			items = append(items, i)
+		}
+		return items, Pagination{PageNumber: pageNumber, PageSize: pageSize, TotalAvailable: total}, nil
+	}
+}
+
+var errPage = errors.New("page failed")
+
+// waitForGoroutines fails the test if number of goroutines doesn't drop to baseline within a second.
+func waitForGoroutines(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines are running, expected at most %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIteratorReturnsItemsInOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		pageSize    int
+		concurrency int
+	}{
+		{name: "empty list", total: 0, pageSize: 3, concurrency: 4},
+		{name: "single page", total: 3, pageSize: 3, concurrency: 4},
+		{name: "sequential pages", total: 13, pageSize: 3, concurrency: 1},
+		{name: "parallel pages", total: 13, pageSize: 3, concurrency: 2},
+		{name: "more workers than pages", total: 20, pageSize: 5, concurrency: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched int32
+			items := newIterator(tt.concurrency, pagedFetch(tt.total, tt.pageSize, 0, &fetched))
+			defer items.Close()
+
+			var got []int
+			for items.Next() {
+				got = append(got, items.Value())
+			}
+
+			if err := items.Err(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(got) != tt.total {
+				t.Fatalf("got %d items, expected %d", len(got), tt.total)
+			}
+			for i, item := range got {
+				if item != i {
+					t.Fatalf("item %d is %d - items are out of order: %v", i, item, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIteratorCloseStopsFetching(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var fetched int32
+	items := newIterator(2, pagedFetch(1000, 10, 0, &fetched))
+	if !items.Next() {
+		t.Fatalf("expected first item, got error %v", items.Err())
+	}
+	items.Close()
+
+	if items.Next() {
+		t.Errorf("Next returned item %d after Close", items.Value())
+	}
+	waitForGoroutines(t, baseline)
+	if n := atomic.LoadInt32(&fetched); n > 4 {
+		t.Errorf("%d of 100 pages were fetched, expected only the first one and those in flight", n)
+	}
+}
+
+func TestIteratorStopsOnPageError(t *testing.T) {
+	tests := []struct {
+		name     string
+		failPage int
+		expected int
+	}{
+		{name: "first page", failPage: 1, expected: 0},
+		{name: "middle page", failPage: 3, expected: 20},
+		{name: "last page", failPage: 5, expected: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := runtime.NumGoroutine()
+
+			var fetched int32
+			items := newIterator(2, pagedFetch(50, 10, tt.failPage, &fetched))
+			defer items.Close()
+
+			var got []int
+			for items.Next() {
+				got = append(got, items.Value())
+			}
+
+			if !errors.Is(items.Err(), errPage) {
+				t.Fatalf("got error %v, expected %v", items.Err(), errPage)
+			}
+			if len(got) != tt.expected {
+				t.Errorf("got %d items before the error, expected %d", len(got), tt.expected)
+			}
+			if items.Next() {
+				t.Errorf("Next returned item %d after the error", items.Value())
+			}
+			waitForGoroutines(t, baseline)
+		})
+	}
+}
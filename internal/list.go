@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListQuery narrows down and orders items of a list, like users or groups. Filter expressions are combined with AND.
+// Filter: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_filtering_and_sorting.htm
+// Fields: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_concepts_fields.htm
+type ListQuery struct {
+	// Filters like siteRole:eq:Creator or lastLogin:gte:2026-01-01T00:00:00Z.
+	Filters []string
+	// Sort like name:asc or lastLogin:desc.
+	Sort []string
+	// Fields like _default_ or _all_; server default fields are returned if empty.
+	Fields []string
+	// PageSize is number of items fetched by one request; DefaultPageSize if 0.
+	PageSize int
+}
+
+// Filter returns filter expression field:operator:value, e.g. Filter("siteRole", "eq", "Creator").
+func Filter(field, operator, value string) string {
+	return fmt.Sprintf("%s:%s:%s", field, operator, value)
+}
+
+// values returns query parameters of the query.
+func (q ListQuery) values() url.Values {
+	values := url.Values{}
+	if len(q.Filters) > 0 {
+		values.Set("filter", strings.Join(q.Filters, ","))
+	}
+	if len(q.Sort) > 0 {
+		values.Set("sort", strings.Join(q.Sort, ","))
+	}
+	if len(q.Fields) > 0 {
+		values.Set("fields", strings.Join(q.Fields, ","))
+	}
+	return values
+}
+
+// list returns iterator of items of paginated list endpoint of the site, e.g. "users" or "groups/group-id/users",
+// matching the query. Every page is decoded into response R, from which page returns items and pagination.
+func list[R any, T any](t *Tableau, endpoint string, query ListQuery,
+	page func(response *R) ([]T, Pagination)) *Iterator[T] {
+	pageSize := query.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	values := query.values()
+	values.Set("pageSize", strconv.Itoa(pageSize))
+	params := values.Encode()
+
+	return newIterator(t.concurrency(), func(pageNumber int) ([]T, Pagination, error) {
+		pageURL := t.siteURL("%s?%s&pageNumber=%d", endpoint, params, pageNumber)
+
+		log.Debugf("Fetching page %d of %d items from %s", pageNumber, pageSize, pageURL)
+
+		var response R
+		if err := t.do(http.MethodGet, pageURL, nil, &response, http.StatusOK); err != nil {
+			return nil, Pagination{}, fmt.Errorf("failed to get page %d of %s: %w", pageNumber, endpoint, err)
+		}
+
+		items, pagination := page(&response)
+
+		log.Debugf("Server returned %d items.", len(items))
+
+		return items, pagination, nil
+	})
+}
+
+// collect returns all items of the iterator; what describes the items in error message.
+func collect[T any](items *Iterator[T], what string) ([]T, error) {
+	defer items.Close()
+
+	res := make([]T, 0)
+	for items.Next() {
+		res = append(res, items.Value())
+	}
+	if err := items.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", what, err)
+	}
+
+	return res, nil
+}
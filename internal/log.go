@@ -8,7 +8,8 @@ import (
 )
 
 func LoggingSetup(cmd *cobra.Command, args []string) {
-	log.SetOutput(os.Stdout)
+	// Standard output is kept for command results.
+	log.SetOutput(os.Stderr)
 	log.SetLevel(log.DebugLevel)
 	level, err := log.ParseLevel(viper.GetString(EnvVarLogLevel))
 	if err != nil {
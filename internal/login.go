@@ -1,23 +1,42 @@
 package internal
 
 import (
-	"bytes"
 	"encoding/xml"
 	"fmt"
 	log "github.com/sirupsen/logrus"
-	"io"
 	"net/http"
+	"time"
 )
 
+type LoginRequest struct {
+	XMLName     xml.Name                `xml:"tsRequest"`
+	Credentials LoginRequestCredentials `xml:"credentials"`
+}
+
+type LoginRequestCredentials struct {
+	Name        string           `xml:"name,attr,omitempty"`
+	Password    string           `xml:"password,attr,omitempty"`
+	TokenName   string           `xml:"personalAccessTokenName,attr,omitempty"`
+	TokenSecret string           `xml:"personalAccessTokenSecret,attr,omitempty"`
+	JWT         string           `xml:"jwt,attr,omitempty"`
+	Site        LoginRequestSite `xml:"site"`
+}
+
+type LoginRequestSite struct {
+	ContentURL string `xml:"contentUrl,attr"`
+}
+
 type LoginResponse struct {
 	XMLName     xml.Name                 `xml:"tsResponse"`
 	Credentials LoginResponseCredentials `xml:"credentials"`
 }
 
 type LoginResponseCredentials struct {
-	Token string            `xml:"token,attr"`
-	Site  LoginResponseSite `xml:"site"`
-	User  LoginResponseUser `xml:"user"`
+	Token string `xml:"token,attr"`
+	// EstimatedTimeToExpiration is formatted as hours:minutes:seconds, e.g. 239:59:58.
+	EstimatedTimeToExpiration string            `xml:"estimatedTimeToExpiration,attr"`
+	Site                      LoginResponseSite `xml:"site"`
+	User                      LoginResponseUser `xml:"user"`
 }
 
 type LoginResponseSite struct {
@@ -28,50 +47,134 @@ type LoginResponseUser struct {
 	ID string `xml:"id,attr"`
 }
 
-func Login(baseURL, username, password string) (token, siteId string, err error) {
-	loginURL := fmt.Sprintf("%s/auth/signin", baseURL)
+// Credentials used to sign in. Connected App JWT issued for Username is used when ClientID is set,
+// personal access token when TokenName is set, username and password otherwise.
+type Credentials struct {
+	Username    string
+	Password    string
+	TokenName   string
+	TokenSecret string
+	ClientID    string
+	SecretID    string
+	SecretValue string
+	Scopes      []string
+}
 
-	var payload = []byte(fmt.Sprintf(`
-<tsRequest>
-  <credentials name="%s" password="%s" >
-    <site contentUrl="" />
-  </credentials>
-</tsRequest>
-`, username, password))
+// UsesJWT returns true if credentials sign in with Connected App JWT.
+func (c Credentials) UsesJWT() bool {
+	return c.ClientID != ""
+}
 
-	req, err := http.NewRequest(http.MethodPost, loginURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return "", "", fmt.Errorf("failed to create login request: %w", err)
+// UsesToken returns true if credentials sign in with personal access token.
+func (c Credentials) UsesToken() bool {
+	return !c.UsesJWT() && c.TokenName != ""
+}
+
+// Identity describes who signs in with the credentials, e.g. "token:my-token".
+func (c Credentials) Identity() string {
+	if c.UsesJWT() {
+		return fmt.Sprintf("jwt:%s:%s", c.ClientID, c.Username)
+	}
+	if c.UsesToken() {
+		return fmt.Sprintf("token:%s", c.TokenName)
 	}
+	return fmt.Sprintf("user:%s", c.Username)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to send login request: %w", err)
+// masked describes the credentials for error messages, with secrets masked.
+func (c Credentials) masked() string {
+	if c.UsesJWT() {
+		return fmt.Sprintf("connected app %s as %s", c.ClientID, c.Username)
+	}
+	if c.UsesToken() {
+		return fmt.Sprintf("token %s:%s", c.TokenName, maskSecret(c.TokenSecret))
+	}
+	return fmt.Sprintf("%s:%s", c.Username, maskSecret(c.Password))
+}
+
+// maskSecret hides all but first and last character of the secret; short secrets are hidden completely.
+func maskSecret(secret string) string {
+	if len(secret) < 8 {
+		return "*****"
 	}
+	return fmt.Sprintf("%s*****%s", secret[0:1], secret[len(secret)-1:])
+}
+
+// Login signs in to the site identified by site content URL; empty site means the Default site.
+// Returns new Session with authentication token, ID of the site and the signed-in user.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_authentication.htm#sign_in
+// API Endpoint: POST /api/api-version/auth/signin
+func Login(baseURL string, credentials Credentials, site string) (*Session, error) {
+	loginURL := fmt.Sprintf("%s/auth/signin", baseURL)
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
+	payload := LoginRequest{
+		Credentials: LoginRequestCredentials{
+			Site: LoginRequestSite{
+				ContentURL: site,
+			},
+		},
+	}
+	if credentials.UsesJWT() {
+		jwt, err := NewConnectedAppJWT(credentials.ClientID, credentials.SecretID, credentials.SecretValue,
+			credentials.Username, credentials.Scopes, time.Now())
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-	}(resp.Body)
+		payload.Credentials.JWT = jwt
+	} else if credentials.UsesToken() {
+		payload.Credentials.TokenName = credentials.TokenName
+		payload.Credentials.TokenSecret = credentials.TokenSecret
+	} else {
+		payload.Credentials.Name = credentials.Username
+		payload.Credentials.Password = credentials.Password
+	}
 
-	body, err := io.ReadAll(resp.Body)
+	now := time.Now()
+
+	var loginResponse LoginResponse
+	if err := sendRequest(http.MethodPost, loginURL, "", payload, &loginResponse, http.StatusOK); err != nil {
+		if HasStatusCode(err, http.StatusUnauthorized) {
+			return nil, fmt.Errorf("failed to log in with invalid credentials (%s): %w", credentials.masked(), err)
+		}
+		return nil, fmt.Errorf("failed to log in: %w", err)
+	}
+
+	lifetime, err := parseTimeToExpiration(loginResponse.Credentials.EstimatedTimeToExpiration)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to read response body: %w", err)
+		log.Debugf("Using default session lifetime: %v", err)
+		lifetime = DefaultSessionLifetime
 	}
 
-	log.Debugln(string(body))
+	return &Session{
+		BaseURL:   baseURL,
+		Site:      site,
+		Identity:  credentials.Identity(),
+		Token:     loginResponse.Credentials.Token,
+		SiteID:    loginResponse.Credentials.Site.ID,
+		UserID:    loginResponse.Credentials.User.ID,
+		ExpiresAt: now.Add(lifetime),
+	}, nil
+}
+
+// Logout signs out of the session identified by the token.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_authentication.htm#sign_out
+// API Endpoint: POST /api/api-version/auth/signout
+func Logout(baseURL, token string) error {
+	logoutURL := fmt.Sprintf("%s/auth/signout", baseURL)
 
-	if resp.StatusCode != http.StatusOK {
-		return "", "", fmt.Errorf("failed to log in - server responded with status code: %d - %s", resp.StatusCode, string(body))
+	if err := sendRequest(http.MethodPost, logoutURL, token, nil, nil, http.StatusNoContent); err != nil {
+		return fmt.Errorf("failed to log out: %w", err)
 	}
 
-	var loginResponse LoginResponse
-	if err := xml.Unmarshal(body, &loginResponse); err != nil {
-		return "", "", fmt.Errorf("unable to unmarshal response body: %w", err)
+	return nil
+}
+
+// parseTimeToExpiration parses hours:minutes:seconds duration returned on sign in.
+func parseTimeToExpiration(value string) (time.Duration, error) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("invalid time to expiration '%s': %w", value, err)
 	}
 
-	return loginResponse.Credentials.Token, loginResponse.Credentials.Site.ID, nil
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
 }
@@ -0,0 +1,304 @@
+// Package render prints command results in text, JSON, YAML, table, CSV or custom Go template format.
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+const (
+	Text     = "text"
+	JSON     = "json"
+	YAML     = "yaml"
+	Table    = "table"
+	CSV      = "csv"
+	Template = "template"
+)
+
+// Formats lists supported formats; template format is used as template=<go-template>.
+var Formats = []string{Text, JSON, YAML, Table, CSV, Template + "=<go-template>"}
+
+// Column of table and CSV formats; Value is Go template rendering the column value of an item, e.g. {{.Username}}.
+type Column struct {
+	Header string
+	Value  string
+}
+
+// View describes how a result is rendered in text, table and CSV formats.
+type View struct {
+	// Text is Go template rendering the result in text format; slices are rendered item by item.
+	Text string
+	// Columns of table and CSV formats; the formats are not available if empty.
+	Columns []Column
+}
+
+// Next returns the next item to render, or false when there are no more items - see Stream.
+type Next func() (interface{}, bool)
+
+// Itemizer is implemented by results which render their items in table, CSV and template formats,
+// e.g. summary of a bulk operation with results of individual operations.
+type Itemizer interface {
+	Items() interface{}
+}
+
+// Validate returns error if the format is not supported.
+func Validate(format string) error {
+	_, _, err := parseFormat(format)
+	return err
+}
+
+// Render writes result v to w in given format. JSON and YAML formats render v as a whole. Text format renders v,
+// or every item if v is a slice. Table, CSV and template formats render items of v, which are elements of v if it's a
+// slice, result of Items if it's an Itemizer, or v itself otherwise.
+func Render(w io.Writer, format string, v interface{}, view View) error {
+	name, tmpl, err := parseFormat(format)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case JSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to render JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case YAML:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to render YAML: %w", err)
+		}
+		_, err = fmt.Fprint(w, string(data))
+		return err
+	case Table:
+		return renderTable(w, each(items(v)), view.Columns)
+	case CSV:
+		return renderCSV(w, each(items(v)), view.Columns)
+	case Template:
+		return renderTemplate(w, each(items(v)), tmpl)
+	default:
+		textTemplate, err := template.New("text").Parse(view.Text)
+		if err != nil {
+			return fmt.Errorf("invalid text template: %w", err)
+		}
+		if reflect.ValueOf(v).Kind() == reflect.Slice {
+			return renderTemplate(w, each(items(v)), textTemplate)
+		}
+		return renderTemplate(w, each([]interface{}{v}), textTemplate)
+	}
+}
+
+// Streams returns true if items can be rendered in the format as they come, without collecting them first - see
+// Stream. JSON and YAML formats render the result as a whole.
+func Streams(format string) bool {
+	name, _, err := parseFormat(format)
+	return err == nil && name != JSON && name != YAML
+}
+
+// Stream writes items returned by next to w in given format, which must be one which Streams; items are rendered as
+// in Render of slice of them. Text, CSV and template formats write every item as it comes, while table format
+// writes all rows at once, as their alignment depends on all items.
+func Stream(w io.Writer, format string, next Next, view View) error {
+	name, tmpl, err := parseFormat(format)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case JSON, YAML:
+		return fmt.Errorf("output format %s can't be streamed", name)
+	case Table:
+		return renderTable(w, next, view.Columns)
+	case CSV:
+		return renderCSV(w, next, view.Columns)
+	case Template:
+		return renderTemplate(w, next, tmpl)
+	default:
+		textTemplate, err := template.New("text").Parse(view.Text)
+		if err != nil {
+			return fmt.Errorf("invalid text template: %w", err)
+		}
+		return renderTemplate(w, next, textTemplate)
+	}
+}
+
+// parseFormat returns name of the format and parsed template of template format.
+func parseFormat(format string) (string, *template.Template, error) {
+	switch format {
+	case Text, "", JSON, YAML, Table, CSV:
+		return format, nil, nil
+	}
+
+	if strings.HasPrefix(format, Template+"=") {
+		tmpl, err := template.New(Template).Parse(strings.TrimPrefix(format, Template+"="))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid output template: %w", err)
+		}
+		return Template, tmpl, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported output format '%s' - use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// items returns items of v to render - see Render.
+func items(v interface{}) []interface{} {
+	if itemizer, ok := v.(Itemizer); ok {
+		v = itemizer.Items()
+	}
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		return []interface{}{v}
+	}
+
+	res := make([]interface{}, value.Len())
+	for i := range res {
+		res[i] = value.Index(i).Interface()
+	}
+	return res
+}
+
+// each returns Next returning given items one by one.
+func each(items []interface{}) Next {
+	return func() (interface{}, bool) {
+		if len(items) == 0 {
+			return nil, false
+		}
+		item := items[0]
+		items = items[1:]
+		return item, true
+	}
+}
+
+// renderTemplate executes the template for every item; every item ends with a new line.
+func renderTemplate(w io.Writer, next Next, tmpl *template.Template) error {
+	for item, ok := next(); ok; item, ok = next() {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, item); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimSuffix(sb.String(), "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rowRenderer renders values of items in columns of table and CSV formats.
+type rowRenderer struct {
+	columns   []Column
+	templates []*template.Template
+}
+
+// newRowRenderer returns renderer of given columns.
+func newRowRenderer(columns []Column) (*rowRenderer, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table and CSV output formats are not available for this command")
+	}
+
+	templates := make([]*template.Template, len(columns))
+	for i, column := range columns {
+		tmpl, err := template.New(column.Header).Parse(column.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template of column %s: %w", column.Header, err)
+		}
+		templates[i] = tmpl
+	}
+
+	return &rowRenderer{columns: columns, templates: templates}, nil
+}
+
+// header returns headers of the columns.
+func (r *rowRenderer) header() []string {
+	header := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		header[i] = column.Header
+	}
+	return header
+}
+
+// row returns values of the item in the columns.
+func (r *rowRenderer) row(item interface{}) ([]string, error) {
+	row := make([]string, len(r.templates))
+	for i, tmpl := range r.templates {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, item); err != nil {
+			return nil, fmt.Errorf("failed to render column %s: %w", r.columns[i].Header, err)
+		}
+		row[i] = sb.String()
+	}
+	return row, nil
+}
+
+// renderTable writes items as columns aligned with spaces, with upper-cased header. Rows are written once all items
+// are rendered, as the alignment depends on all of them.
+func renderTable(w io.Writer, next Next, columns []Column) error {
+	rows, err := newRowRenderer(columns)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	header := rows.header()
+	for i := range header {
+		header[i] = strings.ToUpper(header[i])
+	}
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	for item, ok := next(); ok; item, ok = next() {
+		row, err := rows.row(item)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// renderCSV writes items as CSV with header row.
+func renderCSV(w io.Writer, next Next, columns []Column) error {
+	rows, err := newRowRenderer(columns)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(rows.header()); err != nil {
+		return err
+	}
+	for item, ok := next(); ok; item, ok = next() {
+		row, err := rows.row(item)
+		if err != nil {
+			return err
+		}
+		for i, value := range row {
+			row[i] = escapeFormula(value)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// escapeFormula prefixes value starting with =, +, - or @ with ', so that spreadsheets don't evaluate it as formula.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,194 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+type testSummary struct {
+	Total   int        `json:"total" yaml:"total"`
+	Results []testItem `json:"results" yaml:"results"`
+}
+
+func (s testSummary) Items() interface{} {
+	return s.Results
+}
+
+var testView = View{
+	Text: "{{.Name}} ({{.Count}})",
+	Columns: []Column{
+		{Header: "Name", Value: "{{.Name}}"},
+		{Header: "Count", Value: "{{.Count}}"},
+	},
+}
+
+var testSummaryView = View{
+	Text:    "Total: {{.Total}}",
+	Columns: testView.Columns,
+}
+
+func TestRender(t *testing.T) {
+	items := []testItem{{Name: "first", Count: 1}, {Name: "=SUM(A1)", Count: -2}}
+	summary := testSummary{Total: 2, Results: items}
+
+	tests := []struct {
+		name     string
+		format   string
+		v        interface{}
+		view     View
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "text of item",
+			format:   Text,
+			v:        items[0],
+			view:     testView,
+			expected: "first (1)\n",
+		},
+		{
+			name:     "text of slice is rendered by item",
+			format:   "",
+			v:        items,
+			view:     testView,
+			expected: "first (1)\n=SUM(A1) (-2)\n",
+		},
+		{
+			name:     "text of itemizer renders the whole result",
+			format:   Text,
+			v:        summary,
+			view:     testSummaryView,
+			expected: "Total: 2\n",
+		},
+		{
+			name:     "JSON",
+			format:   JSON,
+			v:        items[0],
+			view:     testView,
+			expected: "{\n  \"name\": \"first\",\n  \"count\": 1\n}\n",
+		},
+		{
+			name:     "YAML",
+			format:   YAML,
+			v:        items[:1],
+			view:     testView,
+			expected: "- name: first\n  count: 1\n",
+		},
+		{
+			name:     "table",
+			format:   Table,
+			v:        items,
+			view:     testView,
+			expected: "NAME       COUNT\nfirst      1\n=SUM(A1)   -2\n",
+		},
+		{
+			name:     "CSV escapes formulas",
+			format:   CSV,
+			v:        items,
+			view:     testView,
+			expected: "Name,Count\nfirst,1\n'=SUM(A1),'-2\n",
+		},
+		{
+			name:     "CSV of itemizer renders its items",
+			format:   CSV,
+			v:        summary,
+			view:     testSummaryView,
+			expected: "Name,Count\nfirst,1\n'=SUM(A1),'-2\n",
+		},
+		{
+			name:     "CSV of item",
+			format:   CSV,
+			v:        items[0],
+			view:     testView,
+			expected: "Name,Count\nfirst,1\n",
+		},
+		{
+			name:     "template",
+			format:   "template={{.Name}};{{.Count}}",
+			v:        items,
+			view:     testView,
+			expected: "first;1\n=SUM(A1);-2\n",
+		},
+		{
+			name:    "table of view without columns",
+			format:  Table,
+			v:       items,
+			view:    View{Text: testView.Text},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			format:  "xml",
+			v:       items,
+			view:    testView,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			format:  "template={{.Name",
+			v:       items,
+			view:    testView,
+			wantErr: true,
+		},
+		{
+			name:    "template of missing field",
+			format:  "template={{.Missing}}",
+			v:       items,
+			view:    testView,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := Render(&sb, tt.format, tt.v, tt.view)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got output %q", sb.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if sb.String() != tt.expected {
+				t.Errorf("got %q, expected %q", sb.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	items := []interface{}{testItem{Name: "first", Count: 1}, testItem{Name: "second", Count: 2}}
+
+	for _, format := range []string{Text, Table, CSV, "template={{.Name}}"} {
+		t.Run(format, func(t *testing.T) {
+			if !Streams(format) {
+				t.Fatalf("format %s doesn't stream", format)
+			}
+
+			var rendered, streamed strings.Builder
+			if err := Render(&rendered, format, items, testView); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := Stream(&streamed, format, each(items), testView); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if streamed.String() != rendered.String() {
+				t.Errorf("streamed %q, rendered %q", streamed.String(), rendered.String())
+			}
+		})
+	}
+
+	for _, format := range []string{JSON, YAML, "xml"} {
+		if Streams(format) {
+			t.Errorf("format %s streams", format)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy of requests to the server. Requests are retried on network errors, 429 Too Many Requests and
+// 502, 503 and 504 responses. Non-idempotent requests (POST) are retried only when the server surely didn't process
+// them - on 429 response or when connection to the server couldn't be established. 500 responses are not retried -
+// Tableau returns them for errors of the request itself, e.g. unexpected input, which fail again when repeated.
+type RetryPolicy struct {
+	// MaxAttempts including the first one; 1 disables retries.
+	MaxAttempts int
+	// Backoff before the first retry; it doubles with every following retry.
+	Backoff time.Duration
+	// MaxBackoff caps the exponential backoff; Retry-After sent by the server takes precedence.
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps the wait requested by the server with Retry-After; 0 means no cap.
+	MaxRetryAfter time.Duration
+	// Jitter is a fraction (0-1) of the backoff which is randomly subtracted from it.
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:   4,
+	Backoff:       time.Second,
+	MaxBackoff:    30 * time.Second,
+	MaxRetryAfter: 2 * time.Minute,
+	Jitter:        0.2,
+}
+
+var (
+	retryPolicy   = DefaultRetryPolicy
+	retryPolicyMu sync.RWMutex
+)
+
+// SetRetryPolicy sets policy used for all requests to the server.
+func SetRetryPolicy(policy RetryPolicy) {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		policy.Jitter = DefaultRetryPolicy.Jitter
+	}
+
+	retryPolicyMu.Lock()
+	defer retryPolicyMu.Unlock()
+
+	retryPolicy = policy
+}
+
+func currentRetryPolicy() RetryPolicy {
+	retryPolicyMu.RLock()
+	defer retryPolicyMu.RUnlock()
+
+	return retryPolicy
+}
+
+// delay returns time to wait before next attempt after given failed attempt.
+func (p RetryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter, time.Now()); ok {
+		if p.MaxRetryAfter > 0 && d > p.MaxRetryAfter {
+			return p.MaxRetryAfter
+		}
+		return d
+	}
+
+	backoff := float64(p.Backoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	backoff -= backoff * p.Jitter * rand.Float64()
+
+	return time.Duration(backoff)
+}
+
+// parseRetryAfter parses Retry-After header value, which is either number of seconds or HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := date.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// retryable returns true if request which failed with the status code (0 if no response was received) and error
+// can be sent again.
+func retryable(method string, status int, err error) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+
+	switch status {
+	case 0:
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "0", expected: 0, ok: true},
+		{value: "120", expected: 2 * time.Minute, ok: true},
+		{value: "-1", ok: false},
+		{value: "soon", ok: false},
+		{value: "Fri, 02 Jan 2026 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{value: "Fri, 02 Jan 2026 11:59:00 GMT", expected: 0, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("got %s, %t, expected %s, %t", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	dialErr := fmt.Errorf("failed to send request: %w", &net.OpError{Op: "dial", Err: errors.New("refused")})
+	readErr := fmt.Errorf("failed to send request: %w", &net.OpError{Op: "read", Err: errors.New("reset")})
+
+	tests := []struct {
+		method   string
+		status   int
+		err      error
+		expected bool
+	}{
+		{method: http.MethodGet, status: 0, err: dialErr, expected: true},
+		{method: http.MethodPost, status: 0, err: dialErr, expected: true},
+		{method: http.MethodGet, status: 0, err: readErr, expected: true},
+		{method: http.MethodPost, status: 0, err: readErr, expected: false},
+		{method: http.MethodGet, status: http.StatusTooManyRequests, expected: true},
+		{method: http.MethodPost, status: http.StatusTooManyRequests, expected: true},
+		{method: http.MethodGet, status: http.StatusBadGateway, expected: true},
+		{method: http.MethodPut, status: http.StatusServiceUnavailable, expected: true},
+		{method: http.MethodDelete, status: http.StatusGatewayTimeout, expected: true},
+		{method: http.MethodPost, status: http.StatusServiceUnavailable, expected: false},
+		{method: http.MethodPatch, status: http.StatusServiceUnavailable, expected: false},
+		{method: http.MethodGet, status: http.StatusInternalServerError, expected: false},
+		{method: http.MethodGet, status: http.StatusNotFound, expected: false},
+		{method: http.MethodGet, status: http.StatusUnauthorized, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %v", tt.method, tt.status, tt.err), func(t *testing.T) {
+			if got := retryable(tt.method, tt.status, tt.err); got != tt.expected {
+				t.Errorf("got %t, expected %t", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second, MaxRetryAfter: time.Minute}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{name: "first backoff", attempt: 1, expected: time.Second},
+		{name: "exponential backoff", attempt: 3, expected: 4 * time.Second},
+		{name: "backoff capped", attempt: 10, expected: 5 * time.Second},
+		{name: "retry after takes precedence", attempt: 1, retryAfter: "30", expected: 30 * time.Second},
+		{name: "retry after capped", attempt: 1, retryAfter: "3600", expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.delay(tt.attempt, tt.retryAfter); got != tt.expected {
+				t.Errorf("got %s, expected %s", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Session of signed-in user, cached in local session file between runs.
+type Session struct {
+	BaseURL   string    `json:"baseUrl" yaml:"baseUrl"`
+	Site      string    `json:"site" yaml:"site"`
+	Identity  string    `json:"identity" yaml:"identity"`
+	Token     string    `json:"token" yaml:"token"`
+	SiteID    string    `json:"siteId" yaml:"siteId"`
+	UserID    string    `json:"userId" yaml:"userId"`
+	ExpiresAt time.Time `json:"expiresAt" yaml:"expiresAt"`
+}
+
+type GetCurrentSessionResponse struct {
+	XMLName xml.Name `xml:"tsResponse"`
+	Session struct {
+		Site LoginResponseSite `xml:"site"`
+		User LoginResponseUser `xml:"user"`
+	} `xml:"session"`
+}
+
+// Matches returns true if session was created on given server and site with given credentials.
+func (s Session) Matches(baseURL, site string, credentials Credentials) bool {
+	return strings.EqualFold(s.BaseURL, baseURL) && strings.EqualFold(s.Site, site) &&
+		s.Identity == credentials.Identity()
+}
+
+// Expired returns true if the session expires before now, including SessionExpirationMargin.
+func (s Session) Expired(now time.Time) bool {
+	return now.Add(SessionExpirationMargin).After(s.ExpiresAt)
+}
+
+// Tableau returns client authenticated with the session.
+func (s Session) Tableau() *Tableau {
+	return &Tableau{
+		BaseURL: s.BaseURL,
+		Token:   s.Token,
+		SiteID:  s.SiteID,
+		UserID:  s.UserID,
+	}
+}
+
+// SessionFilePath returns path of the session file from configuration, or default path in user's cache directory.
+func SessionFilePath() (string, error) {
+	if path := viper.GetString(strings.ToLower(SessionFileVar)); path != "" {
+		return path, nil
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find cache directory: %w", err)
+	}
+
+	return filepath.Join(cacheDir, "tableau-cli", "session.json"), nil
+}
+
+// LoadSession reads session from the file. Returns nil session and no error if the file doesn't exist.
+func LoadSession(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+	}
+
+	return &session, nil
+}
+
+// Save writes session to the file readable only by current user.
+func (s Session) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session file: %w", err)
+	}
+
+	log.Debugf("Session saved to %s", path)
+
+	return nil
+}
+
+// DeleteSession removes the session file, if it exists.
+func DeleteSession(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete session file: %w", err)
+	}
+
+	return nil
+}
+
+// CheckSession verifies the token is still accepted by the server.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_authentication.htm#get-current-session
+// API Endpoint: GET /api/api-version/sessions/current
+func (t *Tableau) CheckSession() error {
+	currentSessionURL := fmt.Sprintf("%s/sessions/current", t.BaseURL)
+
+	var currentSessionResponse GetCurrentSessionResponse
+	if err := t.do(http.MethodGet, currentSessionURL, nil, &currentSessionResponse, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to get current session: %w", err)
+	}
+
+	if currentSessionResponse.Session.Site.ID != t.SiteID {
+		return fmt.Errorf("session belongs to site %s instead of %s", currentSessionResponse.Session.Site.ID, t.SiteID)
+	}
+
+	return nil
+}
+
+// CachedLogin returns session from the session file when it was created for given server, site and credentials,
+// is not expired and the server still accepts it. Otherwise, logs in and saves the new session to the file.
+func CachedLogin(baseURL string, credentials Credentials, site, path string) (*Session, error) {
+	cached, err := LoadSession(path)
+	if err != nil {
+		log.Warnf("Ignoring cached session: %s", err)
+	}
+
+	if cached != nil && cached.Matches(baseURL, site, credentials) && !cached.Expired(time.Now()) {
+		err := cached.Tableau().CheckSession()
+		if err == nil {
+			log.Debugf("Reusing session cached in %s, expires at %s", path, cached.ExpiresAt)
+			return cached, nil
+		}
+		log.Infof("Cached session is no longer valid: %s", err)
+	}
+
+	session, err := Login(baseURL, credentials, site)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Save(path); err != nil {
+		log.Warnf("Failed to cache session: %s", err)
+	}
+
+	return session, nil
+}
+
+// NewTableau returns client authenticated with session from CachedLogin. When the session expires during the run,
+// the client logs in again and updates the session file.
+func NewTableau(baseURL string, credentials Credentials, site, path string) (*Tableau, error) {
+	session, err := CachedLogin(baseURL, credentials, site, path)
+	if err != nil {
+		return nil, err
+	}
+
+	t := session.Tableau()
+	t.Relogin = func() (*Session, error) {
+		session, err := Login(baseURL, credentials, site)
+		if err != nil {
+			return nil, err
+		}
+		if err := session.Save(path); err != nil {
+			log.Warnf("Failed to cache session: %s", err)
+		}
+		return session, nil
+	}
+
+	return t, nil
+}
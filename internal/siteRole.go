@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SiteRoles which can be assigned to users.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#add_user_to_site
+var SiteRoles = []string{
+	"Creator",
+	"Explorer",
+	"ExplorerCanPublish",
+	"ServerAdministrator",
+	"SiteAdministratorCreator",
+	"SiteAdministratorExplorer",
+	"Unlicensed",
+	"Viewer",
+}
+
+// ContentOwnerRoles are site roles which can own content, and so take over assets of deleted users.
+var ContentOwnerRoles = []string{
+	"Creator",
+	"ExplorerCanPublish",
+	"ServerAdministrator",
+	"SiteAdministratorCreator",
+	"SiteAdministratorExplorer",
+}
+
+// LicenseLevels are site roles without administrator rights, from the least to the most capable one - see
+// RaiseSiteRole.
+var LicenseLevels = []string{
+	"Unlicensed",
+	"Viewer",
+	"Explorer",
+	"ExplorerCanPublish",
+	"Creator",
+}
+
+// Administrator rights of site roles - see siteRoleLevels.
+const (
+	noAdmin = iota
+	siteAdmin
+	serverAdmin
+)
+
+// AuthSettings which can be assigned to users.
+var AuthSettings = []string{
+	"ServerDefault",
+	SamlAuthSetting,
+	"OpenID",
+	"TableauIDWithMFA",
+}
+
+// GrantLicenseModes of groups imported from Active Directory - whether members get the minimum site role when they
+// sign in, or when the group is synchronized.
+var GrantLicenseModes = []string{
+	"onLogin",
+	"onSync",
+}
+
+// ValidateSiteRole returns site role as spelled by Tableau (matching is case-insensitive), or error if it's not valid.
+func ValidateSiteRole(role string) (string, error) {
+	return validateValue("site role", role, SiteRoles)
+}
+
+// ValidateAuthSetting returns auth setting as spelled by Tableau (matching is case-insensitive), or error if it's not
+// valid.
+func ValidateAuthSetting(authSetting string) (string, error) {
+	return validateValue("auth setting", authSetting, AuthSettings)
+}
+
+// ValidateGrantLicenseMode returns grant license mode as spelled by Tableau (matching is case-insensitive), or error if
+// it's not valid.
+func ValidateGrantLicenseMode(mode string) (string, error) {
+	return validateValue("grant license mode", mode, GrantLicenseModes)
+}
+
+// CanOwnContent returns true if users with the site role can own content.
+func CanOwnContent(role string) bool {
+	_, err := validateValue("site role", role, ContentOwnerRoles)
+	return err == nil
+}
+
+// siteRoleLevels splits site role into administrator rights and license level, which is index to LicenseLevels.
+// Site administrator Explorer can publish, so its license level is the one of ExplorerCanPublish. Returns false for
+// unknown role.
+func siteRoleLevels(role string) (int, int, bool) {
+	switch strings.ToLower(role) {
+	case "siteadministratorexplorer":
+		return siteAdmin, 3, true
+	case "siteadministratorcreator":
+		return siteAdmin, 4, true
+	case "serveradministrator":
+		return serverAdmin, 4, true
+	}
+	for level, r := range LicenseLevels {
+		if strings.EqualFold(r, role) {
+			return noAdmin, level, true
+		}
+	}
+	return 0, 0, false
+}
+
+// RaiseSiteRole returns site role with administrator rights and license level of the current role, each raised to
+// the one of the required role if it's higher, e.g. SiteAdministratorExplorer raised to Creator is
+// SiteAdministratorCreator. Roles are never lowered. Returns the current role and false if either role is unknown.
+func RaiseSiteRole(current, required string) (string, bool) {
+	currentAdmin, currentLicense, ok := siteRoleLevels(current)
+	requiredAdmin, requiredLicense, requiredOK := siteRoleLevels(required)
+	if !ok || !requiredOK {
+		return current, false
+	}
+
+	admin, license := currentAdmin, currentLicense
+	if requiredAdmin > admin {
+		admin = requiredAdmin
+	}
+	if requiredLicense > license {
+		license = requiredLicense
+	}
+
+	switch {
+	case admin == serverAdmin:
+		return "ServerAdministrator", true
+	case admin == siteAdmin && license == len(LicenseLevels)-1:
+		return "SiteAdministratorCreator", true
+	case admin == siteAdmin:
+		return "SiteAdministratorExplorer", true
+	default:
+		return LicenseLevels[license], true
+	}
+}
+
+func validateValue(kind, value string, valid []string) (string, error) {
+	for _, v := range valid {
+		if strings.EqualFold(v, value) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s '%s' - valid are %s", kind, value, strings.Join(valid, ", "))
+}
@@ -0,0 +1,37 @@
+package internal
+
+import "testing"
+
+func TestRaiseSiteRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		required string
+		expected string
+		known    bool
+	}{
+		{name: "license raised", current: "Viewer", required: "Explorer", expected: "Explorer", known: true},
+		{name: "license never lowered", current: "Creator", required: "Viewer", expected: "Creator", known: true},
+		{name: "same role", current: "explorer", required: "Explorer", expected: "Explorer", known: true},
+		{name: "site admin kept with raised license", current: "SiteAdministratorExplorer", required: "Creator",
+			expected: "SiteAdministratorCreator", known: true},
+		{name: "site admin kept with higher license", current: "SiteAdministratorCreator", required: "Explorer",
+			expected: "SiteAdministratorCreator", known: true},
+		{name: "site admin granted", current: "Creator", required: "SiteAdministratorExplorer",
+			expected: "SiteAdministratorCreator", known: true},
+		{name: "server admin kept", current: "ServerAdministrator", required: "SiteAdministratorCreator",
+			expected: "ServerAdministrator", known: true},
+		{name: "unlicensed raised", current: "Unlicensed", required: "Viewer", expected: "Viewer", known: true},
+		{name: "unknown current role", current: "Boss", required: "Creator", expected: "Boss"},
+		{name: "unknown required role", current: "Viewer", required: "Boss", expected: "Viewer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, known := RaiseSiteRole(tt.current, tt.required)
+			if role != tt.expected || known != tt.known {
+				t.Errorf("got %s, %t, expected %s, %t", role, known, tt.expected, tt.known)
+			}
+		})
+	}
+}
@@ -1,19 +1,78 @@
 package internal
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"sync"
+)
 
 type User struct {
-	Username    string
-	ID          string
-	Role        string
-	AuthSetting string
-	Exists      bool
+	Username           string `json:"username" yaml:"username"`
+	ID                 string `json:"id" yaml:"id"`
+	Role               string `json:"role" yaml:"role"`
+	AuthSetting        string `json:"authSetting" yaml:"authSetting"`
+	Email              string `json:"email" yaml:"email"`
+	FullName           string `json:"fullName" yaml:"fullName"`
+	LastLogin          string `json:"lastLogin" yaml:"lastLogin"`
+	ExternalAuthUserID string `json:"externalAuthUserId" yaml:"externalAuthUserId"`
+	Locale             string `json:"locale" yaml:"locale"`
+	Language           string `json:"language" yaml:"language"`
+	Exists             bool   `json:"exists" yaml:"exists"`
+}
+
+// Group of users on the site; Domain is "local" for local groups. GrantLicenseMode is set only for groups imported
+// from Active Directory - see GrantLicenseModes.
+type Group struct {
+	Name             string `json:"name" yaml:"name"`
+	ID               string `json:"id" yaml:"id"`
+	Domain           string `json:"domain" yaml:"domain"`
+	MinimumSiteRole  string `json:"minimumSiteRole,omitempty" yaml:"minimumSiteRole,omitempty"`
+	GrantLicenseMode string `json:"grantLicenseMode,omitempty" yaml:"grantLicenseMode,omitempty"`
+	Exists           bool   `json:"exists" yaml:"exists"`
+}
+
+// Project on the site; ParentID is empty for top-level projects. Path and Level are set when projects are arranged
+// into tree - see ProjectTree. Path is made of names of the project and its ancestors, separated by
+// ProjectPathSeparator; Level is 0 for top-level projects.
+type Project struct {
+	Name               string `json:"name" yaml:"name"`
+	ID                 string `json:"id" yaml:"id"`
+	Path               string `json:"path" yaml:"path"`
+	Description        string `json:"description,omitempty" yaml:"description,omitempty"`
+	ParentID           string `json:"parentId,omitempty" yaml:"parentId,omitempty"`
+	ContentPermissions string `json:"contentPermissions,omitempty" yaml:"contentPermissions,omitempty"`
+	Level              int    `json:"level" yaml:"level"`
+	Exists             bool   `json:"exists" yaml:"exists"`
+	// MoveToTopLevel makes UpdateProject move the project to the top level, as empty ParentID keeps the parent.
+	MoveToTopLevel bool `json:"-" yaml:"-"`
+}
+
+// Job running asynchronously on the server, e.g. synchronization of a group. CompletedAt is empty until the job
+// completes; FinishCode is 0 on success, 1 on failure and 2 if the job was cancelled.
+type Job struct {
+	ID          string `json:"id" yaml:"id"`
+	Type        string `json:"type" yaml:"type"`
+	Progress    int    `json:"progress" yaml:"progress"`
+	CreatedAt   string `json:"createdAt" yaml:"createdAt"`
+	StartedAt   string `json:"startedAt,omitempty" yaml:"startedAt,omitempty"`
+	CompletedAt string `json:"completedAt,omitempty" yaml:"completedAt,omitempty"`
+	FinishCode  int    `json:"finishCode" yaml:"finishCode"`
+	Notes       string `json:"notes,omitempty" yaml:"notes,omitempty"`
 }
 
 type Tableau struct {
 	BaseURL string
 	Token   string
 	SiteID  string
+	UserID  string
+	// Relogin, if set, is called to sign in again when the session expires - see do.
+	Relogin func() (*Session, error)
+	// Concurrency is maximum number of pages of a list fetched in parallel; DefaultConcurrency if 0.
+	Concurrency int
+	// DryRun, if set, prints requests which would change anything on the server (other than GET) to standard error
+	// instead of sending them - see do.
+	DryRun bool
+
+	mu sync.Mutex
 }
 
 type ErrorResponse struct {
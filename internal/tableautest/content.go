@@ -0,0 +1,83 @@
+package tableautest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Content is a workbook, data source or flow on a site.
+type Content struct {
+	ID      string
+	Name    string
+	OwnerID string
+}
+
+// contentKinds maps endpoints of content to XML element names of their items.
+var contentKinds = map[string]string{
+	"workbooks":   "workbook",
+	"datasources": "datasource",
+	"flows":       "flow",
+}
+
+// AddContent adds workbook, data source or flow (kind is the endpoint name, e.g. "workbooks") owned by given user,
+// and returns it.
+func (site *Site) AddContent(kind, name, ownerID string) *Content {
+	content := &Content{ID: newID(), Name: name, OwnerID: ownerID}
+	site.Content[kind] = append(site.Content[kind], content)
+	return content
+}
+
+// listContent writes page of content of given kind, optionally filtered by owner, e.g. "ownerName:eq:john".
+func (s *Server) listContent(w http.ResponseWriter, r *http.Request, site *Site, kind string, owner *User) {
+	query := r.URL.Query()
+
+	pageSize, pageNumber, ok := pagination(w, query.Get("pageSize"), query.Get("pageNumber"))
+	if !ok {
+		return
+	}
+
+	if filter := query.Get("filter"); filter != "" {
+		if !strings.HasPrefix(filter, "ownerName:eq:") {
+			writeError(w, http.StatusBadRequest, "400065", "Bad Request", "unsupported filter "+filter)
+			return
+		}
+		if owner = site.User(strings.TrimPrefix(filter, "ownerName:eq:")); owner == nil {
+			owner = &User{}
+		}
+	}
+
+	var items []*Content
+	for _, content := range site.Content[kind] {
+		if owner == nil || content.OwnerID == owner.ID {
+			items = append(items, content)
+		}
+	}
+
+	var sb strings.Builder
+	for i := (pageNumber - 1) * pageSize; i < len(items) && i < pageNumber*pageSize; i++ {
+		sb.WriteString(fmt.Sprintf(`<%s id="%s" name="%s"><owner id="%s"/></%s>`, contentKinds[kind], items[i].ID,
+			escape(items[i].Name), items[i].OwnerID, contentKinds[kind]))
+	}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<pagination pageNumber="%d" pageSize="%d" totalAvailable="%d"/>`+
+		`<%s>%s</%s>`, pageNumber, pageSize, len(items), kind, sb.String(), kind))
+}
+
+// transferContent moves content owned by user from to user to, or removes it if to is nil - as when user is deleted
+// without mapAssetsTo.
+func (site *Site) transferContent(from, to *User) {
+	for kind, items := range site.Content {
+		kept := items[:0]
+		for _, content := range items {
+			if content.OwnerID == from.ID {
+				if to == nil {
+					continue
+				}
+				content.OwnerID = to.ID
+			}
+			kept = append(kept, content)
+		}
+		site.Content[kind] = kept
+	}
+}
@@ -0,0 +1,318 @@
+package tableautest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Group on a site. Domain is "local" for local groups, otherwise the group is imported from Active Directory.
+type Group struct {
+	ID               string
+	Name             string
+	Domain           string
+	MinimumSiteRole  string
+	GrantLicenseMode string
+	// UserIDs of members.
+	UserIDs []string
+}
+
+type groupRequest struct {
+	XMLName xml.Name `xml:"tsRequest"`
+	Group   struct {
+		Name            string `xml:"name,attr"`
+		MinimumSiteRole string `xml:"minimumSiteRole,attr"`
+		Import          *struct {
+			Source           string `xml:"source,attr"`
+			DomainName       string `xml:"domainName,attr"`
+			SiteRole         string `xml:"siteRole,attr"`
+			GrantLicenseMode string `xml:"grantLicenseMode,attr"`
+		} `xml:"import"`
+	} `xml:"group"`
+}
+
+// AddGroup adds group to the site and returns it. ID is generated if empty, domain defaults to local.
+func (site *Site) AddGroup(g Group) *Group {
+	if g.ID == "" {
+		g.ID = newID()
+	}
+	if g.Domain == "" {
+		g.Domain = "local"
+	}
+	group := &g
+	site.Groups = append(site.Groups, group)
+	return group
+}
+
+// Group returns group with given name (case-insensitive), or nil.
+func (site *Site) Group(name string) *Group {
+	for _, group := range site.Groups {
+		if strings.EqualFold(group.Name, name) {
+			return group
+		}
+	}
+	return nil
+}
+
+// element returns group as XML element; imported groups tell their site role and grant license mode in import element.
+func (g *Group) element() string {
+	if g.Domain == "local" {
+		return fmt.Sprintf(`<group id="%s" name="%s" minimumSiteRole="%s"><domain name="%s"/></group>`, g.ID,
+			escape(g.Name), g.MinimumSiteRole, escape(g.Domain))
+	}
+	return fmt.Sprintf(`<group id="%s" name="%s"><domain name="%s"/><import domainName="%s" siteRole="%s" `+
+		`grantLicenseMode="%s"/></group>`, g.ID, escape(g.Name), escape(g.Domain), escape(g.Domain),
+		g.MinimumSiteRole, g.GrantLicenseMode)
+}
+
+// field returns value of group field as used in filter and sort expressions.
+func (g *Group) field(name string) (string, bool) {
+	switch name {
+	case "name":
+		return g.Name, true
+	case "domainName":
+		return g.Domain, true
+	case "minimumSiteRole":
+		return g.MinimumSiteRole, true
+	default:
+		return "", false
+	}
+}
+
+// groups handles /sites/site-id/groups endpoints; path is the rest after groups.
+func (s *Server) groups(w http.ResponseWriter, r *http.Request, session *session, path []string) {
+	site := session.site
+	groupID := ""
+	if len(path) > 0 {
+		groupID = path[0]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && groupID == "":
+		s.listGroups(w, r, site)
+	case r.Method == http.MethodPost && groupID == "":
+		var req groupRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if req.Group.Name == "" {
+			writeError(w, http.StatusBadRequest, "400000", "Bad Request", "name is required")
+			return
+		}
+		if site.Group(req.Group.Name) != nil {
+			writeError(w, http.StatusConflict, "409009", "Conflict",
+				fmt.Sprintf("group %s already exists on site", req.Group.Name))
+			return
+		}
+		g := Group{Name: req.Group.Name, MinimumSiteRole: req.Group.MinimumSiteRole}
+		if imp := req.Group.Import; imp != nil {
+			if imp.DomainName == "" || imp.SiteRole == "" {
+				writeError(w, http.StatusBadRequest, "400000", "Bad Request", "domainName and siteRole are required")
+				return
+			}
+			g.Domain, g.MinimumSiteRole, g.GrantLicenseMode = imp.DomainName, imp.SiteRole, imp.GrantLicenseMode
+		}
+		group := site.AddGroup(g)
+		writeResponse(w, http.StatusCreated, group.element())
+	case r.Method == http.MethodPut && groupID != "" && len(path) == 1:
+		group := site.groupByID(groupID)
+		if group == nil {
+			writeError(w, http.StatusNotFound, "404011", "Resource Not Found", "group not found")
+			return
+		}
+		var req groupRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if imp := req.Group.Import; imp != nil {
+			s.syncGroup(w, r, site, group, imp.SiteRole, imp.GrantLicenseMode)
+			return
+		}
+		if req.Group.Name != "" {
+			if other := site.Group(req.Group.Name); other != nil && other != group {
+				writeError(w, http.StatusConflict, "409009", "Conflict",
+					fmt.Sprintf("group %s already exists on site", req.Group.Name))
+				return
+			}
+			group.Name = req.Group.Name
+		}
+		if req.Group.MinimumSiteRole != "" {
+			group.MinimumSiteRole = req.Group.MinimumSiteRole
+		}
+		writeResponse(w, http.StatusOK, group.element())
+	case r.Method == http.MethodDelete && groupID != "" && len(path) == 1:
+		group := site.groupByID(groupID)
+		if group == nil {
+			writeError(w, http.StatusNotFound, "404011", "Resource Not Found", "group not found")
+			return
+		}
+		site.removeGroup(group)
+		w.WriteHeader(http.StatusNoContent)
+	case groupID != "" && len(path) >= 2 && path[1] == "users":
+		group := site.groupByID(groupID)
+		if group == nil {
+			writeError(w, http.StatusNotFound, "404011", "Resource Not Found", "group not found")
+			return
+		}
+		s.groupUsers(w, r, site, group, path[2:])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "405000", "Method Not Allowed", r.Method+" "+r.URL.Path)
+	}
+}
+
+// syncGroup "synchronizes" group imported from Active Directory - the stand-in has no directory, so members don't
+// change. Only asynchronous synchronization (asJob=true) is supported; the job completes immediately.
+func (s *Server) syncGroup(w http.ResponseWriter, r *http.Request, site *Site, group *Group, siteRole, mode string) {
+	if group.Domain == "local" {
+		writeError(w, http.StatusBadRequest, "400000", "Bad Request", "local group can't be synchronized")
+		return
+	}
+	if r.URL.Query().Get("asJob") != "true" {
+		writeError(w, http.StatusBadRequest, "400000", "Bad Request", "only asJob=true is supported")
+		return
+	}
+	if siteRole != "" {
+		group.MinimumSiteRole = siteRole
+	}
+	if mode != "" {
+		group.GrantLicenseMode = mode
+	}
+	job := site.addJob("GroupSync")
+	writeResponse(w, http.StatusAccepted, job.element())
+}
+
+// listGroups writes page of groups matching filter, in given sort order.
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request, site *Site) {
+	query := r.URL.Query()
+
+	pageSize, pageNumber, ok := pagination(w, query.Get("pageSize"), query.Get("pageNumber"))
+	if !ok {
+		return
+	}
+
+	groups := make([]*Group, 0, len(site.Groups))
+	for _, group := range site.Groups {
+		match, err := matches(group.field, query.Get("filter"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+			return
+		}
+		if match {
+			groups = append(groups, group)
+		}
+	}
+
+	if err := sortItems(groups, (*Group).field, query.Get("sort")); err != nil {
+		writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	for i := (pageNumber - 1) * pageSize; i < len(groups) && i < pageNumber*pageSize; i++ {
+		sb.WriteString(groups[i].element())
+	}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<pagination pageNumber="%d" pageSize="%d" totalAvailable="%d"/>`+
+		`<groups>%s</groups>`, pageNumber, pageSize, len(groups), sb.String()))
+}
+
+// groupUsers handles /sites/site-id/groups/group-id/users endpoints; path is the rest after users.
+func (s *Server) groupUsers(w http.ResponseWriter, r *http.Request, site *Site, group *Group, path []string) {
+	switch {
+	case r.Method == http.MethodGet && len(path) == 0:
+		var members []*User
+		for _, id := range group.UserIDs {
+			members = append(members, site.userByID(id))
+		}
+		s.writeUsers(w, r, members)
+	case r.Method == http.MethodPost && len(path) == 0:
+		var req struct {
+			User struct {
+				ID string `xml:"id,attr"`
+			} `xml:"user"`
+		}
+		if !readRequest(w, r, &req) {
+			return
+		}
+		user := site.userByID(req.User.ID)
+		if user == nil {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user not found")
+			return
+		}
+		if group.hasMember(user.ID) {
+			writeError(w, http.StatusConflict, "409011", "Conflict", "user is already a member of the group")
+			return
+		}
+		group.UserIDs = append(group.UserIDs, user.ID)
+		writeResponse(w, http.StatusOK, fmt.Sprintf(`<user %s/>`, user.attrs()))
+	case r.Method == http.MethodDelete && len(path) == 1:
+		if !group.hasMember(path[0]) {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user is not a member of the group")
+			return
+		}
+		group.removeMember(path[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "405000", "Method Not Allowed", r.Method+" "+r.URL.Path)
+	}
+}
+
+// userGroups writes page of groups the user is member of.
+func (s *Server) userGroups(w http.ResponseWriter, r *http.Request, site *Site, user *User) {
+	pageSize, pageNumber, ok := pagination(w, r.URL.Query().Get("pageSize"), r.URL.Query().Get("pageNumber"))
+	if !ok {
+		return
+	}
+
+	var groups []*Group
+	for _, group := range site.Groups {
+		if group.hasMember(user.ID) {
+			groups = append(groups, group)
+		}
+	}
+
+	var sb strings.Builder
+	for i := (pageNumber - 1) * pageSize; i < len(groups) && i < pageNumber*pageSize; i++ {
+		sb.WriteString(groups[i].element())
+	}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<pagination pageNumber="%d" pageSize="%d" totalAvailable="%d"/>`+
+		`<groups>%s</groups>`, pageNumber, pageSize, len(groups), sb.String()))
+}
+
+func (g *Group) hasMember(userID string) bool {
+	for _, id := range g.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Group) removeMember(userID string) {
+	for i, id := range g.UserIDs {
+		if id == userID {
+			g.UserIDs = append(g.UserIDs[:i], g.UserIDs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (site *Site) groupByID(id string) *Group {
+	for _, group := range site.Groups {
+		if group.ID == id {
+			return group
+		}
+	}
+	return nil
+}
+
+func (site *Site) removeGroup(group *Group) {
+	for i, g := range site.Groups {
+		if g == group {
+			site.Groups = append(site.Groups[:i], site.Groups[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package tableautest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Job run on a site. Jobs started by the stand-in complete successfully as soon as they're created; job without
+// CompletedAt added to the site is running forever.
+type Job struct {
+	ID          string
+	Type        string
+	CreatedAt   string
+	CompletedAt string
+}
+
+// addJob adds completed job of given type to the site and returns it.
+func (site *Site) addJob(jobType string) *Job {
+	now := time.Now().UTC().Format("2006-01-02T15:04:05Z")
+	job := &Job{ID: newID(), Type: jobType, CreatedAt: now, CompletedAt: now}
+	site.Jobs = append(site.Jobs, job)
+	return job
+}
+
+// element returns job as XML element.
+func (j *Job) element() string {
+	if j.CompletedAt == "" {
+		return fmt.Sprintf(`<job id="%s" mode="Asynchronous" type="%s" progress="0" createdAt="%s" startedAt="%s"/>`,
+			j.ID, j.Type, j.CreatedAt, j.CreatedAt)
+	}
+	return fmt.Sprintf(`<job id="%s" mode="Asynchronous" type="%s" progress="100" createdAt="%s" startedAt="%s" `+
+		`completedAt="%s" finishCode="0"/>`, j.ID, j.Type, j.CreatedAt, j.CreatedAt, j.CompletedAt)
+}
+
+// jobs handles /sites/site-id/jobs endpoints; path is the rest after jobs.
+func (s *Server) jobs(w http.ResponseWriter, r *http.Request, site *Site, path []string) {
+	if r.Method != http.MethodGet || len(path) != 1 {
+		writeError(w, http.StatusMethodNotAllowed, "405000", "Method Not Allowed", r.Method+" "+r.URL.Path)
+		return
+	}
+	for _, job := range site.Jobs {
+		if job.ID == path[0] {
+			writeResponse(w, http.StatusOK, job.element())
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "404003", "Resource Not Found", "job not found")
+}
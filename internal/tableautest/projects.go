@@ -0,0 +1,208 @@
+package tableautest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Project on a site. ParentID is empty for top-level projects.
+type Project struct {
+	ID                 string
+	Name               string
+	Description        string
+	ParentID           string
+	ContentPermissions string
+}
+
+type projectRequest struct {
+	XMLName xml.Name `xml:"tsRequest"`
+	Project struct {
+		ParentProjectID    *string `xml:"parentProjectId,attr"`
+		Name               string  `xml:"name,attr"`
+		Description        string  `xml:"description,attr"`
+		ContentPermissions string  `xml:"contentPermissions,attr"`
+	} `xml:"project"`
+}
+
+// AddProject adds project to the site and returns it. ID is generated if empty, content permissions default to
+// ManagedByOwner.
+func (site *Site) AddProject(p Project) *Project {
+	if p.ID == "" {
+		p.ID = newID()
+	}
+	if p.ContentPermissions == "" {
+		p.ContentPermissions = "ManagedByOwner"
+	}
+	project := &p
+	site.Projects = append(site.Projects, project)
+	return project
+}
+
+// element returns project as XML element.
+func (p *Project) element() string {
+	return fmt.Sprintf(`<project id="%s" name="%s" description="%s" parentProjectId="%s" contentPermissions="%s"/>`,
+		p.ID, escape(p.Name), escape(p.Description), p.ParentID, p.ContentPermissions)
+}
+
+// field returns value of project field as used in filter and sort expressions.
+func (p *Project) field(name string) (string, bool) {
+	switch name {
+	case "name":
+		return p.Name, true
+	case "parentProjectId":
+		return p.ParentID, true
+	case "topLevelProject":
+		return fmt.Sprint(p.ParentID == ""), true
+	default:
+		return "", false
+	}
+}
+
+// projects handles /sites/site-id/projects endpoints; path is the rest after projects.
+func (s *Server) projects(w http.ResponseWriter, r *http.Request, site *Site, path []string) {
+	var project *Project
+	if len(path) > 0 {
+		if project = site.projectByID(path[0]); project == nil {
+			writeError(w, http.StatusNotFound, "404005", "Resource Not Found", "project not found")
+			return
+		}
+	}
+
+	var req projectRequest
+	if (r.Method == http.MethodPost || r.Method == http.MethodPut) && !readRequest(w, r, &req) {
+		return
+	}
+	reqParentID := ""
+	if req.Project.ParentProjectID != nil {
+		reqParentID = *req.Project.ParentProjectID
+	}
+	if reqParentID != "" && site.projectByID(reqParentID) == nil {
+		writeError(w, http.StatusNotFound, "404005", "Resource Not Found", "parent project not found")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && project == nil:
+		s.listProjects(w, r, site)
+	case r.Method == http.MethodPost && project == nil:
+		if req.Project.Name == "" {
+			writeError(w, http.StatusBadRequest, "400000", "Bad Request", "name is required")
+			return
+		}
+		if site.project(reqParentID, req.Project.Name) != nil {
+			writeError(w, http.StatusConflict, "409006", "Conflict",
+				fmt.Sprintf("project %s already exists in the parent project", req.Project.Name))
+			return
+		}
+		project = site.AddProject(Project{Name: req.Project.Name, Description: req.Project.Description,
+			ParentID: reqParentID, ContentPermissions: req.Project.ContentPermissions})
+		writeResponse(w, http.StatusCreated, project.element())
+	case r.Method == http.MethodPut && project != nil:
+		parentID, name := project.ParentID, project.Name
+		if req.Project.ParentProjectID != nil {
+			parentID = reqParentID
+		}
+		if req.Project.Name != "" {
+			name = req.Project.Name
+		}
+		for id := parentID; id != ""; id = site.projectByID(id).ParentID {
+			if id == project.ID {
+				writeError(w, http.StatusBadRequest, "400000", "Bad Request", "project can't be moved into itself")
+				return
+			}
+		}
+		if other := site.project(parentID, name); other != nil && other != project {
+			writeError(w, http.StatusConflict, "409006", "Conflict",
+				fmt.Sprintf("project %s already exists in the parent project", name))
+			return
+		}
+		project.ParentID, project.Name = parentID, name
+		if req.Project.Description != "" {
+			project.Description = req.Project.Description
+		}
+		if req.Project.ContentPermissions != "" {
+			project.ContentPermissions = req.Project.ContentPermissions
+		}
+		writeResponse(w, http.StatusOK, project.element())
+	case r.Method == http.MethodDelete && project != nil && len(path) == 1:
+		if project.Name == "Default" && project.ParentID == "" {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "default project can't be deleted")
+			return
+		}
+		site.removeProject(project)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "405000", "Method Not Allowed", r.Method+" "+r.URL.Path)
+	}
+}
+
+// listProjects writes page of projects matching filter, in given sort order.
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, site *Site) {
+	query := r.URL.Query()
+
+	pageSize, pageNumber, ok := pagination(w, query.Get("pageSize"), query.Get("pageNumber"))
+	if !ok {
+		return
+	}
+
+	projects := make([]*Project, 0, len(site.Projects))
+	for _, project := range site.Projects {
+		match, err := matches(project.field, query.Get("filter"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+			return
+		}
+		if match {
+			projects = append(projects, project)
+		}
+	}
+
+	if err := sortItems(projects, (*Project).field, query.Get("sort")); err != nil {
+		writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	for i := (pageNumber - 1) * pageSize; i < len(projects) && i < pageNumber*pageSize; i++ {
+		sb.WriteString(projects[i].element())
+	}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<pagination pageNumber="%d" pageSize="%d" totalAvailable="%d"/>`+
+		`<projects>%s</projects>`, pageNumber, pageSize, len(projects), sb.String()))
+}
+
+// project returns project with given name (case-insensitive) in the parent project, or nil.
+func (site *Site) project(parentID, name string) *Project {
+	for _, project := range site.Projects {
+		if project.ParentID == parentID && strings.EqualFold(project.Name, name) {
+			return project
+		}
+	}
+	return nil
+}
+
+func (site *Site) projectByID(id string) *Project {
+	for _, project := range site.Projects {
+		if project.ID == id {
+			return project
+		}
+	}
+	return nil
+}
+
+// removeProject removes the project and its nested projects.
+func (site *Site) removeProject(project *Project) {
+	for _, p := range append([]*Project{}, site.Projects...) {
+		if p.ParentID == project.ID {
+			site.removeProject(p)
+		}
+	}
+	for i, p := range site.Projects {
+		if p == project {
+			site.Projects = append(site.Projects[:i], site.Projects[i+1:]...)
+			return
+		}
+	}
+}
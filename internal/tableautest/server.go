@@ -0,0 +1,434 @@
+// Package tableautest provides in-memory stand-in for Tableau Server REST API, for testing without a live server.
+//
+// Server implements sign in and out with password, personal access token or Connected App JWT, sites selected by
+// content URL, users and groups endpoints with pagination, filters and sorting, import and synchronization of groups
+// from Active Directory as jobs, nested projects, and listing of workbooks, data sources and flows by owner. Errors
+// are returned as Tableau error responses, and failures can be injected with Fail and ExpireSessions.
+package tableautest
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersion is used in BaseURL; server accepts any version.
+const APIVersion = "3.11"
+
+// Site on the server. Default site has empty content URL.
+type Site struct {
+	ID         string
+	Name       string
+	ContentURL string
+	Users      []*User
+	Groups     []*Group
+	Projects   []*Project
+	// Content by endpoint name - workbooks, datasources and flows.
+	Content map[string][]*Content
+	Jobs    []*Job
+}
+
+// Server is the stand-in Tableau Server. All fields and sites can be modified only before the server is used,
+// or while holding Lock.
+type Server struct {
+	*httptest.Server
+
+	// Username and Password accepted by sign in; the user is added to every site as SiteAdministratorCreator.
+	Username string
+	Password string
+	// Tokens maps accepted personal access token names to their secrets.
+	Tokens map[string]string
+	// ConnectedApps maps accepted Connected App client IDs to their secrets. JWTs have to be signed (HS256) with
+	// the secret, for the "tableau" audience, and not expired.
+	ConnectedApps map[string]ConnectedApp
+	// Sites by content URL.
+	Sites map[string]*Site
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	failures []failure
+	// Requests counts received requests by "METHOD path", e.g. "GET /sites/site-id/users".
+	Requests map[string]int
+}
+
+// ConnectedApp with direct trust; SecretID is the key ID of JWTs signed with SecretValue.
+type ConnectedApp struct {
+	SecretID    string
+	SecretValue string
+}
+
+type session struct {
+	site    *Site
+	user    *User
+	expired bool
+}
+
+type failure struct {
+	status  int
+	code    string
+	retries int
+}
+
+// NewServer starts server with Default site and admin user "admin" with password "password".
+// Stop it with Close.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns server with Default site, which has to be started with Start or StartTLS.
+func NewUnstartedServer() *Server {
+	s := &Server{
+		Username:      "admin",
+		Password:      "password",
+		Tokens:        map[string]string{},
+		ConnectedApps: map[string]ConnectedApp{},
+		Sites:         map[string]*Site{},
+		sessions:      map[string]*session{},
+		Requests:      map[string]int{},
+	}
+	s.AddSite("")
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// BaseURL returns URL of the API, as used in TABLEAU_URL.
+func (s *Server) BaseURL() string {
+	return fmt.Sprintf("%s/api/%s", s.URL, APIVersion)
+}
+
+// Lock the server state, e.g. to inspect or modify users while the server is running.
+func (s *Server) Lock() {
+	s.mu.Lock()
+}
+
+// Unlock the server state.
+func (s *Server) Unlock() {
+	s.mu.Unlock()
+}
+
+// AddSite adds site with given content URL and Default project, unless it exists already, and returns it.
+func (s *Server) AddSite(contentURL string) *Site {
+	if site, ok := s.Sites[strings.ToLower(contentURL)]; ok {
+		return site
+	}
+
+	name := contentURL
+	if name == "" {
+		name = "Default"
+	}
+	site := &Site{ID: newID(), Name: name, ContentURL: contentURL, Content: map[string][]*Content{}}
+	site.AddProject(Project{Name: "Default",
+		Description: "The default project that was automatically created by Tableau."})
+	s.Sites[strings.ToLower(contentURL)] = site
+	return site
+}
+
+// AddUser adds user to the site and returns it. ID is generated if empty, as are defaults of site role and
+// auth setting.
+func (site *Site) AddUser(u User) *User {
+	if u.ID == "" {
+		u.ID = newID()
+	}
+	if u.SiteRole == "" {
+		u.SiteRole = "Viewer"
+	}
+	if u.AuthSetting == "" {
+		u.AuthSetting = "ServerDefault"
+	}
+	user := &u
+	site.Users = append(site.Users, user)
+	return user
+}
+
+// User returns user with given name (case-insensitive), or nil.
+func (site *Site) User(name string) *User {
+	for _, user := range site.Users {
+		if strings.EqualFold(user.Name, name) {
+			return user
+		}
+	}
+	return nil
+}
+
+// Fail makes next count requests (other than sign in and out) fail with given status and Tableau error code;
+// empty code defaults to status followed by 000. Failures are queued after already injected ones.
+func (s *Server) Fail(count, status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if code == "" {
+		code = fmt.Sprintf("%d000", status)
+	}
+	s.failures = append(s.failures, failure{status: status, code: code, retries: count})
+}
+
+// ExpireSessions makes all current sessions expired, so that they are rejected with 401002 error.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		session.expired = true
+	}
+}
+
+// Sessions returns number of active sessions.
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := 0
+	for _, session := range s.sessions {
+		if !session.expired {
+			res++
+		}
+	}
+	return res
+}
+
+// ServeHTTP routes requests under /api/<version>/.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.SplitN(strings.Trim(r.URL.Path, "/"), "/", 3)
+	if len(parts) < 3 || parts[0] != "api" {
+		writeError(w, http.StatusNotFound, "404000", "Resource Not Found", "unknown endpoint "+r.URL.Path)
+		return
+	}
+	path := strings.Split(parts[2], "/")
+	s.Requests[r.Method+" /"+strings.Join(path, "/")]++
+
+	switch {
+	case r.Method == http.MethodPost && parts[2] == "auth/signin":
+		s.signIn(w, r)
+		return
+	case r.Method == http.MethodPost && parts[2] == "auth/signout":
+		s.signOut(w, r)
+		return
+	}
+
+	if len(s.failures) > 0 {
+		f := &s.failures[0]
+		f.retries--
+		if f.retries <= 0 {
+			s.failures = s.failures[1:]
+		}
+		writeError(w, f.status, f.code, http.StatusText(f.status), "injected failure")
+		return
+	}
+
+	session, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && parts[2] == "sessions/current":
+		s.currentSession(w, session)
+	case len(path) >= 3 && path[0] == "sites" && path[2] == "users":
+		if path[1] != session.site.ID {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "token belongs to another site")
+			return
+		}
+		s.users(w, r, session, path[3:])
+	case len(path) >= 3 && path[0] == "sites" && path[2] == "groups":
+		if path[1] != session.site.ID {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "token belongs to another site")
+			return
+		}
+		s.groups(w, r, session, path[3:])
+	case len(path) >= 3 && path[0] == "sites" && path[2] == "jobs":
+		if path[1] != session.site.ID {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "token belongs to another site")
+			return
+		}
+		s.jobs(w, r, session.site, path[3:])
+	case len(path) >= 3 && path[0] == "sites" && path[2] == "projects":
+		if path[1] != session.site.ID {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "token belongs to another site")
+			return
+		}
+		s.projects(w, r, session.site, path[3:])
+	case r.Method == http.MethodGet && len(path) == 3 && path[0] == "sites" && contentKinds[path[2]] != "":
+		if path[1] != session.site.ID {
+			writeError(w, http.StatusForbidden, "403000", "Forbidden", "token belongs to another site")
+			return
+		}
+		s.listContent(w, r, session.site, path[2], nil)
+	default:
+		writeError(w, http.StatusNotFound, "404000", "Resource Not Found", "unknown endpoint "+r.URL.Path)
+	}
+}
+
+// authenticate returns session of the X-Tableau-Auth token, or writes error response.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	session, ok := s.sessions[r.Header.Get("X-Tableau-Auth")]
+	if !ok || session.expired {
+		writeError(w, http.StatusUnauthorized, "401002", "Unauthorized Access",
+			"Invalid authentication credentials were provided.")
+		return nil, false
+	}
+	return session, true
+}
+
+type signInRequest struct {
+	XMLName     xml.Name `xml:"tsRequest"`
+	Credentials struct {
+		Name        string `xml:"name,attr"`
+		Password    string `xml:"password,attr"`
+		TokenName   string `xml:"personalAccessTokenName,attr"`
+		TokenSecret string `xml:"personalAccessTokenSecret,attr"`
+		JWT         string `xml:"jwt,attr"`
+		Site        struct {
+			ContentURL string `xml:"contentUrl,attr"`
+		} `xml:"site"`
+	} `xml:"credentials"`
+}
+
+func (s *Server) signIn(w http.ResponseWriter, r *http.Request) {
+	var req signInRequest
+	if !readRequest(w, r, &req) {
+		return
+	}
+	c := req.Credentials
+
+	site, ok := s.Sites[strings.ToLower(c.Site.ContentURL)]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "401001", "Signin Error",
+			fmt.Sprintf("site '%s' does not exist", c.Site.ContentURL))
+		return
+	}
+
+	var username string
+	switch {
+	case c.JWT != "":
+		username, ok = s.jwtSubject(c.JWT)
+	case c.TokenName != "":
+		secret, exists := s.Tokens[c.TokenName]
+		username, ok = s.Username, exists && secret == c.TokenSecret
+	default:
+		username, ok = s.Username, c.Name == s.Username && c.Password == s.Password
+	}
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "401001", "Signin Error",
+			"Error signing in to Tableau Server (invalid credentials)")
+		return
+	}
+
+	user := site.User(username)
+	if user == nil && username == s.Username {
+		user = site.AddUser(User{Name: s.Username, SiteRole: "SiteAdministratorCreator"})
+	}
+	if user == nil {
+		writeError(w, http.StatusUnauthorized, "401001", "Signin Error",
+			fmt.Sprintf("user %s does not exist on site", username))
+		return
+	}
+
+	token := newID()
+	s.sessions[token] = &session{site: site, user: user}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(
+		`<credentials token="%s" estimatedTimeToExpiration="239:59:59"><site id="%s" contentUrl="%s"/>`+
+			`<user id="%s"/></credentials>`, token, site.ID, escape(site.ContentURL), user.ID))
+}
+
+// jwtSubject returns subject of valid JWT signed with secret of known Connected App.
+func (s *Server) jwtSubject(jwt string) (string, bool) {
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+		Issuer    string `json:"iss"`
+	}
+	var claims struct {
+		Issuer    string `json:"iss"`
+		ExpiresAt int64  `json:"exp"`
+		Audience  string `json:"aud"`
+		Subject   string `json:"sub"`
+	}
+	if !decodeJWTPart(jwt, 0, &header) || !decodeJWTPart(jwt, 1, &claims) {
+		return "", false
+	}
+	app, ok := s.ConnectedApps[header.Issuer]
+	if !ok || header.Algorithm != "HS256" || header.KeyID != app.SecretID || claims.Issuer != header.Issuer ||
+		claims.Audience != "tableau" || time.Now().Unix() >= claims.ExpiresAt {
+		return "", false
+	}
+
+	unsigned := jwt[:strings.LastIndex(jwt, ".")]
+	signature, err := base64.RawURLEncoding.DecodeString(jwt[len(unsigned)+1:])
+	if err != nil {
+		return "", false
+	}
+	mac := hmac.New(sha256.New, []byte(app.SecretValue))
+	mac.Write([]byte(unsigned))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+func (s *Server) signOut(w http.ResponseWriter, r *http.Request) {
+	delete(s.sessions, r.Header.Get("X-Tableau-Auth"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) currentSession(w http.ResponseWriter, session *session) {
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<session><site id="%s" contentUrl="%s"/><user id="%s" name="%s"/>`+
+		`</session>`, session.site.ID, escape(session.site.ContentURL), session.user.ID, escape(session.user.Name)))
+}
+
+// readRequest decodes XML request body, or writes error response.
+func readRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = xml.Unmarshal(body, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "400000", "Bad Request", fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// writeResponse writes tsResponse with given inner XML.
+func writeResponse(w http.ResponseWriter, status int, inner string) {
+	w.Header().Set("Content-Type", "application/xml;charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><tsResponse xmlns="http://tableau.com/api">%s`+
+		`</tsResponse>`, inner)
+}
+
+// writeError writes Tableau error response.
+func writeError(w http.ResponseWriter, status int, code, summary, detail string) {
+	writeResponse(w, status, fmt.Sprintf(`<error code="%s"><summary>%s</summary><detail>%s</detail></error>`,
+		code, escape(summary), escape(detail)))
+}
+
+// escape returns text escaped for XML.
+func escape(text string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(text))
+	return sb.String()
+}
+
+// newID returns random ID formatted as UUID.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", id[0:8], id[8:12], id[12:16], id[16:20], id[20:])
+}
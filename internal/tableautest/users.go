@@ -0,0 +1,354 @@
+package tableautest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// User on a site. LastLogin is formatted as 2006-01-02T15:04:05Z; it's empty if the user never logged in.
+type User struct {
+	ID                 string
+	Name               string
+	SiteRole           string
+	AuthSetting        string
+	Email              string
+	FullName           string
+	LastLogin          string
+	ExternalAuthUserID string
+	Locale             string
+	Language           string
+}
+
+// contentOwnerRoles are site roles, in lower case, of users who can own content, and so take over assets of deleted
+// users.
+var contentOwnerRoles = map[string]bool{
+	"creator":                   true,
+	"explorercanpublish":        true,
+	"serveradministrator":       true,
+	"siteadministratorcreator":  true,
+	"siteadministratorexplorer": true,
+}
+
+type userRequest struct {
+	XMLName xml.Name `xml:"tsRequest"`
+	User    struct {
+		Name        string `xml:"name,attr"`
+		SiteRole    string `xml:"siteRole,attr"`
+		AuthSetting string `xml:"authSetting,attr"`
+		Email       string `xml:"email,attr"`
+		FullName    string `xml:"fullName,attr"`
+	} `xml:"user"`
+}
+
+// attrs returns user as XML attributes.
+func (u *User) attrs() string {
+	return fmt.Sprintf(`id="%s" name="%s" siteRole="%s" authSetting="%s" email="%s" fullName="%s" lastLogin="%s" `+
+		`externalAuthUserId="%s" locale="%s" language="%s"`, u.ID, escape(u.Name), u.SiteRole, u.AuthSetting,
+		escape(u.Email), escape(u.FullName), u.LastLogin, escape(u.ExternalAuthUserID), u.Locale, u.Language)
+}
+
+// field returns value of user field as used in filter and sort expressions.
+func (u *User) field(name string) (string, bool) {
+	switch name {
+	case "name":
+		return u.Name, true
+	case "siteRole":
+		return u.SiteRole, true
+	case "authSetting":
+		return u.AuthSetting, true
+	case "email":
+		return u.Email, true
+	case "friendlyName":
+		return u.FullName, true
+	case "lastLogin":
+		return u.LastLogin, true
+	default:
+		return "", false
+	}
+}
+
+// users handles /sites/site-id/users endpoints; path is the rest after users.
+func (s *Server) users(w http.ResponseWriter, r *http.Request, session *session, path []string) {
+	site := session.site
+	userID := ""
+	if len(path) > 0 {
+		userID = path[0]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && userID == "":
+		s.writeUsers(w, r, site.Users)
+	case r.Method == http.MethodGet && len(path) == 2 && path[1] == "groups":
+		user := site.userByID(userID)
+		if user == nil {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user not found")
+			return
+		}
+		s.userGroups(w, r, site, user)
+	case r.Method == http.MethodGet && len(path) == 2 && path[1] == "workbooks":
+		user := site.userByID(userID)
+		if user == nil {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user not found")
+			return
+		}
+		s.listContent(w, r, site, "workbooks", user)
+	case r.Method == http.MethodPost && userID == "":
+		var req userRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if req.User.Name == "" || req.User.SiteRole == "" {
+			writeError(w, http.StatusBadRequest, "400000", "Bad Request", "name and siteRole are required")
+			return
+		}
+		if site.User(req.User.Name) != nil {
+			writeError(w, http.StatusConflict, "409017", "Conflict",
+				fmt.Sprintf("user %s already exists on site", req.User.Name))
+			return
+		}
+		user := site.AddUser(User{Name: req.User.Name, SiteRole: req.User.SiteRole, AuthSetting: req.User.AuthSetting})
+		writeResponse(w, http.StatusCreated, fmt.Sprintf(`<user %s/>`, user.attrs()))
+	case r.Method == http.MethodPut && userID != "":
+		user := site.userByID(userID)
+		if user == nil {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user not found")
+			return
+		}
+		var req userRequest
+		if !readRequest(w, r, &req) {
+			return
+		}
+		if req.User.Name != "" {
+			user.Name = req.User.Name
+		}
+		if req.User.SiteRole != "" {
+			user.SiteRole = req.User.SiteRole
+		}
+		if req.User.AuthSetting != "" {
+			user.AuthSetting = req.User.AuthSetting
+		}
+		if req.User.Email != "" {
+			user.Email = req.User.Email
+		}
+		if req.User.FullName != "" {
+			user.FullName = req.User.FullName
+		}
+		writeResponse(w, http.StatusOK, fmt.Sprintf(`<user %s/>`, user.attrs()))
+	case r.Method == http.MethodDelete && userID != "":
+		user := site.userByID(userID)
+		if user == nil {
+			writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "user not found")
+			return
+		}
+		var assetsUser *User
+		if mapAssetsTo := r.URL.Query().Get("mapAssetsTo"); mapAssetsTo != "" {
+			if assetsUser = site.userByID(mapAssetsTo); assetsUser == nil {
+				writeError(w, http.StatusNotFound, "404002", "Resource Not Found", "mapAssetsTo user not found")
+				return
+			}
+			if !contentOwnerRoles[strings.ToLower(assetsUser.SiteRole)] {
+				writeError(w, http.StatusBadRequest, "400000", "Bad Request",
+					fmt.Sprintf("mapAssetsTo user has site role %s, which can't own content", assetsUser.SiteRole))
+				return
+			}
+		}
+		site.transferContent(user, assetsUser)
+		for _, group := range site.Groups {
+			group.removeMember(user.ID)
+		}
+		site.removeUser(user)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "405000", "Method Not Allowed", r.Method+" "+r.URL.Path)
+	}
+}
+
+// writeUsers writes page of given users matching filter, in given sort order.
+func (s *Server) writeUsers(w http.ResponseWriter, r *http.Request, all []*User) {
+	query := r.URL.Query()
+
+	pageSize, pageNumber, ok := pagination(w, query.Get("pageSize"), query.Get("pageNumber"))
+	if !ok {
+		return
+	}
+
+	users := make([]*User, 0, len(all))
+	for _, user := range all {
+		match, err := matches(user.field, query.Get("filter"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+			return
+		}
+		if match {
+			users = append(users, user)
+		}
+	}
+
+	if err := sortItems(users, (*User).field, query.Get("sort")); err != nil {
+		writeError(w, http.StatusBadRequest, "400065", "Bad Request", err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	for i := (pageNumber - 1) * pageSize; i < len(users) && i < pageNumber*pageSize; i++ {
+		sb.WriteString(fmt.Sprintf(`<user %s/>`, users[i].attrs()))
+	}
+
+	writeResponse(w, http.StatusOK, fmt.Sprintf(`<pagination pageNumber="%d" pageSize="%d" totalAvailable="%d"/>`+
+		`<users>%s</users>`, pageNumber, pageSize, len(users), sb.String()))
+}
+
+// pagination parses page size (default 100, max 1000) and page number (default 1), or writes error response.
+func pagination(w http.ResponseWriter, size, number string) (int, int, bool) {
+	pageSize, pageNumber := 100, 1
+	var err error
+	if size != "" {
+		if pageSize, err = strconv.Atoi(size); err != nil || pageSize < 1 || pageSize > 1000 {
+			writeError(w, http.StatusBadRequest, "400006", "Bad Request", "invalid page size "+size)
+			return 0, 0, false
+		}
+	}
+	if number != "" {
+		if pageNumber, err = strconv.Atoi(number); err != nil || pageNumber < 1 {
+			writeError(w, http.StatusBadRequest, "400007", "Bad Request", "invalid page number "+number)
+			return 0, 0, false
+		}
+	}
+	return pageSize, pageNumber, true
+}
+
+// matches returns true if item, whose fields are returned by field, matches all expressions of the filter,
+// e.g. "name:eq:john,siteRole:in:[Viewer,Creator]". Supported operators are eq, in, has, gt, gte, lt and lte.
+func matches(field func(name string) (string, bool), filter string) (bool, error) {
+	for _, expression := range splitFilter(filter) {
+		parts := strings.SplitN(expression, ":", 3)
+		if len(parts) != 3 {
+			return false, fmt.Errorf("invalid filter expression %s", expression)
+		}
+		value, ok := field(parts[0])
+		if !ok {
+			return false, fmt.Errorf("unsupported filter field %s", parts[0])
+		}
+		operand := parts[2]
+
+		var match bool
+		switch parts[1] {
+		case "eq":
+			match = strings.EqualFold(value, operand)
+		case "in":
+			for _, item := range strings.Split(strings.Trim(operand, "[]"), ",") {
+				match = match || strings.EqualFold(value, item)
+			}
+		case "has":
+			match = strings.Contains(strings.ToLower(value), strings.ToLower(operand))
+		case "gt":
+			match = value > operand
+		case "gte":
+			match = value >= operand
+		case "lt":
+			match = value != "" && value < operand
+		case "lte":
+			match = value != "" && value <= operand
+		default:
+			return false, fmt.Errorf("unsupported filter operator %s", parts[1])
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// splitFilter splits filter into expressions separated by commas outside of [] lists.
+func splitFilter(filter string) []string {
+	var res []string
+	depth, start := 0, 0
+	for i, c := range filter {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, filter[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(filter) {
+		res = append(res, filter[start:])
+	}
+	return res
+}
+
+// sortItems sorts items, whose fields are returned by field, by expression like "siteRole:asc,name:desc".
+func sortItems[T any](items []T, field func(item T, name string) (string, bool), expression string) error {
+	if expression == "" {
+		return nil
+	}
+
+	type key struct {
+		field string
+		desc  bool
+	}
+	var keys []key
+	for _, part := range strings.Split(expression, ",") {
+		name, direction, _ := strings.Cut(part, ":")
+		if len(items) > 0 {
+			if _, ok := field(items[0], name); !ok {
+				return fmt.Errorf("unsupported sort field %s", name)
+			}
+		}
+		if direction != "asc" && direction != "desc" {
+			return fmt.Errorf("invalid sort direction %s", direction)
+		}
+		keys = append(keys, key{field: name, desc: direction == "desc"})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, k := range keys {
+			a, _ := field(items[i], k.field)
+			b, _ := field(items[j], k.field)
+			if a != b {
+				return (a < b) != k.desc
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func (site *Site) userByID(id string) *User {
+	for _, user := range site.Users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return nil
+}
+
+func (site *Site) removeUser(user *User) {
+	for i, u := range site.Users {
+		if u == user {
+			site.Users = append(site.Users[:i], site.Users[i+1:]...)
+			return
+		}
+	}
+}
+
+// decodeJWTPart decodes JSON of given part of JWT.
+func decodeJWTPart(jwt string, part int, v interface{}) bool {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[part])
+	return err == nil && json.Unmarshal(data, v) == nil
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type UpdateGroupRequest struct {
+	XMLName xml.Name                `xml:"tsRequest"`
+	Group   UpdateGroupRequestGroup `xml:"group"`
+}
+
+type UpdateGroupRequestGroup struct {
+	Name            string       `xml:"name,attr,omitempty"`
+	MinimumSiteRole string       `xml:"minimumSiteRole,attr,omitempty"`
+	Import          *GroupImport `xml:"import,omitempty"`
+}
+
+type UpdateGroupResponse struct {
+	XMLName xml.Name              `xml:"tsResponse"`
+	Group   GetGroupResponseGroup `xml:"group"`
+}
+
+// UpdateGroup renames group identified by ID and changes its minimum site role; empty properties are not changed.
+// Returns the updated group.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_group
+// API Endpoint: PUT /api/api-version/sites/site-id/groups/group-id
+func (t *Tableau) UpdateGroup(g Group) (*Group, error) {
+	updateGroupURL := t.siteURL("groups/%s", g.ID)
+
+	payload := UpdateGroupRequest{
+		Group: UpdateGroupRequestGroup{
+			Name:            g.Name,
+			MinimumSiteRole: g.MinimumSiteRole,
+		},
+	}
+
+	log.Debugf("Updating group on URL %s", updateGroupURL)
+
+	var updateGroupResponse UpdateGroupResponse
+	if err := t.do(http.MethodPut, updateGroupURL, payload, &updateGroupResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to update group: %w", err)
+	}
+
+	if t.DryRun {
+		g.Exists = true
+		return &g, nil
+	}
+
+	return updateGroupResponse.Group.toGroup(), nil
+}
+
+// SyncGroup starts synchronization of group imported from Active Directory, identified by ID, and returns the job
+// doing it - see GetJob. Minimum site role and grant license mode of the group are set at the same time; minimum site
+// role defaults to DefaultImportSiteRole.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_group
+// API Endpoint: PUT /api/api-version/sites/site-id/groups/group-id?asJob=true
+func (t *Tableau) SyncGroup(g Group) (*Job, error) {
+	importGroup := importOf(g)
+	if importGroup == nil {
+		return nil, fmt.Errorf("group %s is local - only groups imported from Active Directory can be synchronized",
+			g.Name)
+	}
+
+	syncGroupURL := t.siteURL("groups/%s?asJob=true", g.ID)
+	payload := UpdateGroupRequest{
+		Group: UpdateGroupRequestGroup{
+			Name:   g.Name,
+			Import: importGroup,
+		},
+	}
+
+	log.Debugf("Synchronizing group on URL %s", syncGroupURL)
+
+	var jobResponse JobResponse
+	if err := t.do(http.MethodPut, syncGroupURL, payload, &jobResponse, http.StatusAccepted); err != nil {
+		return nil, fmt.Errorf("failed to synchronize group: %w", err)
+	}
+
+	if t.DryRun {
+		return &Job{ID: DryRunID, Type: GroupSyncJobType}, nil
+	}
+
+	return jobResponse.Job.toJob(), nil
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/davidlukac/go-tableau-cli/internal/tableautest"
+	"net/http"
+	"testing"
+)
+
+func TestSyncGroup(t *testing.T) {
+	server := newTestServer(t)
+	tableau := newTestTableau(t, server)
+
+	site := server.Sites[""]
+	local := site.AddGroup(tableautest.Group{Name: "Finance"})
+	imported := site.AddGroup(tableautest.Group{Name: "Sales", Domain: "example.com", MinimumSiteRole: "Viewer",
+		GrantLicenseMode: "onLogin"})
+
+	endpoint := fmt.Sprintf("%s /sites/%s/groups/%s", http.MethodPut, tableau.SiteID, local.ID)
+	if _, err := tableau.SyncGroup(Group{Name: local.Name, ID: local.ID, Domain: LocalDomain}); err == nil {
+		t.Errorf("expected error synchronizing local group")
+	}
+	if n := server.Requests[endpoint]; n != 0 {
+		t.Errorf("sent %d requests to synchronize local group", n)
+	}
+
+	job, err := tableau.SyncGroup(Group{Name: imported.Name, ID: imported.ID, Domain: imported.Domain,
+		MinimumSiteRole: "Explorer", GrantLicenseMode: "onSync"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if job.ID == "" || job.Type != GroupSyncJobType {
+		t.Errorf("got job %+v, expected %s job", job, GroupSyncJobType)
+	}
+
+	server.Lock()
+	defer server.Unlock()
+	if imported.MinimumSiteRole != "Explorer" || imported.GrantLicenseMode != "onSync" {
+		t.Errorf("group has role %s and mode %s, expected Explorer and onSync", imported.MinimumSiteRole,
+			imported.GrantLicenseMode)
+	}
+}
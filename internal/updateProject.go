@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type UpdateProjectRequest struct {
+	XMLName xml.Name              `xml:"tsRequest"`
+	Project ProjectRequestProject `xml:"project"`
+}
+
+// UpdateProject renames project identified by ID, moves it to parent project given by ParentID, or to the top level if
+// MoveToTopLevel is set, and changes its description and content permissions; empty properties are not changed.
+// Returns the updated project; its Path is not set.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_projects.htm#update_project
+// API Endpoint: PUT /api/api-version/sites/site-id/projects/project-id
+func (t *Tableau) UpdateProject(p Project) (*Project, error) {
+	updateProjectURL := t.siteURL("projects/%s", p.ID)
+
+	payload := UpdateProjectRequest{
+		Project: ProjectRequestProject{
+			Name:               p.Name,
+			Description:        p.Description,
+			ContentPermissions: p.ContentPermissions,
+		},
+	}
+	if p.MoveToTopLevel {
+		p.ParentID = ""
+	}
+	if p.ParentID != "" || p.MoveToTopLevel {
+		payload.Project.ParentProjectID = &p.ParentID
+	}
+
+	log.Debugf("Updating project on URL %s", updateProjectURL)
+
+	var updateProjectResponse ProjectResponse
+	if err := t.do(http.MethodPut, updateProjectURL, payload, &updateProjectResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	if t.DryRun {
+		p.Exists = true
+		return &p, nil
+	}
+
+	return updateProjectResponse.Project.toProject(), nil
+}
@@ -1,16 +1,25 @@
 package internal
 
 import (
-	"bytes"
 	"encoding/xml"
 	"fmt"
 	log "github.com/sirupsen/logrus"
-	"github.com/spf13/viper"
-	"io"
 	"net/http"
 	"strings"
 )
 
+type UpdateUserRequest struct {
+	XMLName xml.Name              `xml:"tsRequest"`
+	User    UpdateUserRequestUser `xml:"user"`
+}
+
+type UpdateUserRequestUser struct {
+	FullName    string `xml:"fullName,attr,omitempty"`
+	Email       string `xml:"email,attr,omitempty"`
+	SiteRole    string `xml:"siteRole,attr,omitempty"`
+	AuthSetting string `xml:"authSetting,attr,omitempty"`
+}
+
 type UpdateUserResponse struct {
 	XLMName xml.Name               `xml:"tsResponse"`
 	User    UpdateUserResponseUser `xml:"user"`
@@ -29,7 +38,7 @@ type UpdateUserResponseUser struct {
 // Returns User object with exists set to false if user was not found.
 // API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_user
 // API Endpoint: PUT /api/api-version/sites/site-id/users/user-id
-func (t Tableau) UpdateUserSiteRole(username, siteRole string) (*User, error) {
+func (t *Tableau) UpdateUserSiteRole(username, siteRole string) (*User, error) {
 	user, err := t.GetUser(username)
 	if err != nil {
 		return nil, err
@@ -42,65 +51,24 @@ func (t Tableau) UpdateUserSiteRole(username, siteRole string) (*User, error) {
 		return user, nil
 	}
 
-	updateUserURL := fmt.Sprintf("%s/sites/%s/users/%s", t.BaseURL, t.SiteID, user.ID)
-
-	var payload = []byte(fmt.Sprintf(`
-<tsRequest>
-  <user siteRole="%s" />
-</tsRequest>
-`, siteRole))
-
-	log.Debugf("Updating user on URL %s", updateUserURL)
-
-	req, err := http.NewRequest(http.MethodPut, updateUserURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create update_user request: %w", err)
-	}
-
-	req.Header.Set("X-Tableau-Auth", t.Token)
+	updateUserURL := t.siteURL("users/%s", user.ID)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send update_user request: %w", err)
+	payload := UpdateUserRequest{
+		User: UpdateUserRequestUser{
+			SiteRole: siteRole,
+		},
 	}
 
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			panic(err)
-		}
-	}(resp.Body)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	log.Debugf("Updating user on URL %s", updateUserURL)
 
-	log.Debugf("response body: %s", string(body))
-	log.Debugf("response code: %d", resp.StatusCode)
-
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusUnauthorized {
-			password := viper.GetString("tableau_password")
-			return nil, fmt.Errorf("invalid credentials [%d] (%s:%s)", resp.StatusCode,
-				viper.GetString("tableau_username"),
-				fmt.Sprintf("%s*****%s", password[0:1], password[len(password)-1:]))
-		}
-
-		var updateUserErrorResponse ErrorResponse
-		if err := xml.Unmarshal(body, &updateUserErrorResponse); err != nil {
-			return nil, fmt.Errorf("unable to unmarshal response body: %w", err)
-		}
-
-		return nil, fmt.Errorf("failed to update user - server responded with status code: %d, Code: %s, "+
-			"Summary: %s, Detail: %s", resp.StatusCode, updateUserErrorResponse.Error.Code,
-			updateUserErrorResponse.Error.Summary, updateUserErrorResponse.Error.Detail)
+	var updateUserResponse UpdateUserResponse
+	if err := t.do(http.MethodPut, updateUserURL, payload, &updateUserResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	var updateUserResponse UpdateUserResponse
-	if err := xml.Unmarshal(body, &updateUserResponse); err != nil {
-		return nil, fmt.Errorf("unable to unmarshal response body: %w", err)
+	if t.DryRun {
+		user.Role = siteRole
+		return user, nil
 	}
 
 	user, err = t.GetUser(username)
@@ -115,3 +83,44 @@ func (t Tableau) UpdateUserSiteRole(username, siteRole string) (*User, error) {
 
 	return user, nil
 }
+
+// UpdateUser updates properties of existing user identified by ID - full name, email, site role and auth setting -
+// which are set in u; empty properties are not changed.
+// Returns the updated user.
+// API Doc: https://help.tableau.com/current/api/rest_api/en-us/REST/rest_api_ref_users_and_groups.htm#update_user
+// API Endpoint: PUT /api/api-version/sites/site-id/users/user-id
+func (t *Tableau) UpdateUser(u User) (*User, error) {
+	updateUserURL := t.siteURL("users/%s", u.ID)
+
+	payload := UpdateUserRequest{
+		User: UpdateUserRequestUser{
+			FullName:    u.FullName,
+			Email:       u.Email,
+			SiteRole:    u.Role,
+			AuthSetting: u.AuthSetting,
+		},
+	}
+
+	log.Debugf("Updating user on URL %s", updateUserURL)
+
+	var updateUserResponse UpdateUserResponse
+	if err := t.do(http.MethodPut, updateUserURL, payload, &updateUserResponse, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if t.DryRun {
+		u.Exists = true
+		return &u, nil
+	}
+
+	updated := updateUserResponse.User
+	return &User{
+		Exists:      true,
+		ID:          u.ID,
+		Username:    updated.Name,
+		FullName:    updated.FullName,
+		Email:       updated.Email,
+		Role:        updated.SiteRole,
+		AuthSetting: updated.AuthSetting,
+	}, nil
+}
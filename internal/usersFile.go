@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadUsersFile reads list of users from CSV file (with .csv extension) or YAML file (otherwise).
+// YAML file is a list of users with keys username, role, authSetting, email and fullName.
+// CSV file has a header row naming the columns the same way (case-insensitive; name, siteRole and full name are
+// accepted too). Only username is required; other values are empty when missing.
+func ReadUsersFile(path string) ([]*User, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open users file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var users []*User
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		users, err = readUsersCSV(file)
+	} else {
+		err = yaml.NewDecoder(file).Decode(&users)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse users file %s: %w", path, err)
+	}
+
+	for idx, user := range users {
+		if user == nil || strings.TrimSpace(user.Username) == "" {
+			return nil, fmt.Errorf("failed to parse users file %s: user %d has no username", path, idx+1)
+		}
+	}
+
+	return users, nil
+}
+
+// readUsersCSV reads users from CSV with header row - see ReadUsersFile.
+func readUsersCSV(r io.Reader) ([]*User, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]func(u *User) *string, len(header))
+	hasUsername := false
+	for i, column := range header {
+		switch strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(column)) {
+		case "username", "name":
+			fields[i] = func(u *User) *string { return &u.Username }
+			hasUsername = true
+		case "role", "siterole":
+			fields[i] = func(u *User) *string { return &u.Role }
+		case "authsetting":
+			fields[i] = func(u *User) *string { return &u.AuthSetting }
+		case "email":
+			fields[i] = func(u *User) *string { return &u.Email }
+		case "fullname":
+			fields[i] = func(u *User) *string { return &u.FullName }
+		default:
+			return nil, fmt.Errorf("unknown column '%s'", column)
+		}
+	}
+	if !hasUsername {
+		return nil, fmt.Errorf("missing username column")
+	}
+
+	var users []*User
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		user := &User{}
+		for i, value := range record {
+			*fields[i](user) = strings.TrimSpace(value)
+		}
+		users = append(users, user)
+	}
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadUsersCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		expected []*User
+		wantErr  bool
+	}{
+		{
+			name: "all columns",
+			csv: "username,role,authSetting,email,fullName\n" +
+				"john.smith,Creator,SAML,john@example.com,John Smith\n",
+			expected: []*User{{Username: "john.smith", Role: "Creator", AuthSetting: "SAML",
+				Email: "john@example.com", FullName: "John Smith"}},
+		},
+		{
+			name:     "alternative column names in any order",
+			csv:      "Full Name, Site Role, Name\n  Jane Doe , Viewer, jane.doe\n",
+			expected: []*User{{Username: "jane.doe", Role: "Viewer", FullName: "Jane Doe"}},
+		},
+		{
+			name:     "username only",
+			csv:      "user_name\na\nb\n",
+			expected: []*User{{Username: "a"}, {Username: "b"}},
+		},
+		{
+			name: "empty file",
+			csv:  "",
+		},
+		{
+			name:    "unknown column",
+			csv:     "username,department\njohn.smith,Finance\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing username column",
+			csv:     "role\nCreator\n",
+			wantErr: true,
+		},
+		{
+			name:    "wrong number of values",
+			csv:     "username,role\njohn.smith\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readUsersCSV(strings.NewReader(tt.csv))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadUsersFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected []*User
+		wantErr  bool
+	}{
+		{
+			name: "YAML",
+			file: "users.yaml",
+			content: "- username: john.smith\n  role: Creator\n  fullName: John Smith\n" +
+				"- username: jane.doe\n",
+			expected: []*User{{Username: "john.smith", Role: "Creator", FullName: "John Smith"},
+				{Username: "jane.doe"}},
+		},
+		{
+			name:     "CSV by extension",
+			file:     "users.CSV",
+			content:  "username,role\njohn.smith,Creator\n",
+			expected: []*User{{Username: "john.smith", Role: "Creator"}},
+		},
+		{
+			name:    "empty YAML",
+			file:    "users.yaml",
+			content: "",
+		},
+		{
+			name:    "user without username",
+			file:    "users.yaml",
+			content: "- username: john.smith\n- role: Creator\n",
+			wantErr: true,
+		},
+		{
+			name:    "blank username in CSV",
+			file:    "users.csv",
+			content: "username,role\n ,Creator\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid YAML",
+			file:    "users.yaml",
+			content: "username: john.smith\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadUsersFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := ReadUsersFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}